@@ -3,6 +3,9 @@ package main
 import (
 	"fmt"
 	"github.com/spf13/cobra"
+	"golang.org/x/tools/go/packages"
+	"io"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"todoapp/lib/errors/generate"
@@ -10,13 +13,20 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
-func generateErrors(errorTags map[string]generate.ErrorMap) {
+const generatedPath = "pkg/errors/errors.go"
+
+func generateErrors(errorTags map[string]generate.ErrorMap, requiredLocales []string) {
 	err := generate.Validate(errorTags)
 	if err != nil {
 		panic(err)
 	}
 
-	output, err := os.OpenFile("pkg/errors/errors.go", os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	err = generate.ValidateLocales(errorTags, requiredLocales)
+	if err != nil {
+		panic(err)
+	}
+
+	output, err := os.OpenFile(generatedPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
 	if err != nil {
 		panic(err)
 	}
@@ -33,11 +43,125 @@ func generateErrors(errorTags map[string]generate.ErrorMap) {
 }
 
 func generateCmd(errorTags map[string]generate.ErrorMap) *cobra.Command {
-	return &cobra.Command{
+	var requiredLocales []string
+
+	cmd := &cobra.Command{
 		Use:   "generate",
 		Short: "generate pkg/errors/errors.go from errors.yml",
 		Run: func(cmd *cobra.Command, args []string) {
-			generateErrors(errorTags)
+			generateErrors(errorTags, requiredLocales)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&requiredLocales, "required-locales", []string{"vi"}, "locales every error must have a message for")
+	return cmd
+}
+
+// writeOutput calls fn with the file at path, or with stdout when path is empty
+func writeOutput(path string, fn func(output io.Writer) error) {
+	if path == "" {
+		if err := fn(os.Stdout); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	output, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0666)
+	if err != nil {
+		panic(err)
+	}
+
+	err = fn(output)
+	if err != nil {
+		panic(err)
+	}
+
+	err = output.Close()
+	if err != nil {
+		panic(err)
+	}
+}
+
+func docsCmd(errorTags map[string]generate.ErrorMap) *cobra.Command {
+	var format string
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "docs",
+		Short: "generate the catalog of error codes from errors.yml",
+		Run: func(cmd *cobra.Command, args []string) {
+			writeOutput(outputPath, func(output io.Writer) error {
+				return generate.GenerateDocs(errorTags, generate.DocsFormat(format), output)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", string(generate.DocsFormatMarkdown), "markdown or html")
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "output file, stdout when empty")
+	return cmd
+}
+
+func typeScriptCmd(errorTags map[string]generate.ErrorMap) *cobra.Command {
+	var outputPath string
+
+	cmd := &cobra.Command{
+		Use:   "typescript",
+		Short: "generate the TypeScript types of the gateway error bodies from errors.yml",
+		Run: func(cmd *cobra.Command, args []string) {
+			err := generate.Validate(errorTags)
+			if err != nil {
+				panic(err)
+			}
+
+			writeOutput(outputPath, func(output io.Writer) error {
+				return generate.GenerateTypeScript(errorTags, output)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputPath, "output", "o", "", "output file, stdout when empty")
+	return cmd
+}
+
+func checkErrors(errorTags map[string]generate.ErrorMap) []generate.CheckIssue {
+	err := generate.Validate(errorTags)
+	if err != nil {
+		panic(err)
+	}
+
+	generated, err := ioutil.ReadFile(generatedPath)
+	if err != nil {
+		panic(err)
+	}
+
+	issues, err := generate.CheckDrift(errorTags, generatedPath, generated)
+	if err != nil {
+		panic(err)
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: generate.CheckLoadMode}, "./...")
+	if err != nil {
+		panic(err)
+	}
+	if packages.PrintErrors(pkgs) > 0 {
+		panic("failed to load packages")
+	}
+
+	return append(issues, generate.CheckUsages(errorTags, pkgs)...)
+}
+
+func checkCmd(errorTags map[string]generate.ErrorMap) *cobra.Command {
+	return &cobra.Command{
+		Use:   "check",
+		Short: "check errors.yml against pkg/errors/errors.go and the usages of the errors",
+		Run: func(cmd *cobra.Command, args []string) {
+			issues := checkErrors(errorTags)
+			for _, issue := range issues {
+				fmt.Println(issue)
+			}
+			if len(issues) > 0 {
+				os.Exit(1)
+			}
 		},
 	}
 }
@@ -88,6 +212,9 @@ func main() {
 
 	rootCmd.AddCommand(
 		generateCmd(errorTags),
+		docsCmd(errorTags),
+		typeScriptCmd(errorTags),
+		checkCmd(errorTags),
 		nextErrorCodeCmd(errorTags),
 	)
 
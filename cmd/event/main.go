@@ -16,11 +16,14 @@ import (
 	"todoapp/event"
 	"todoapp/lib/dblib"
 	"todoapp/lib/errors"
-	"todoapp/lib/mysql"
+	"todoapp/lib/log"
+	"todoapp/todoapp/repo"
 
 	_ "github.com/go-sql-driver/mysql"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 )
@@ -35,6 +38,10 @@ func main() {
 	rootCmd.AddCommand(
 		startCommand(),
 		checkSQLCommand(),
+		verifyCommand(),
+		archiveCommand(),
+		rebuildProjectionCommand(),
+		backfillAggregateIDsCommand(),
 	)
 
 	err := rootCmd.Execute()
@@ -49,7 +56,11 @@ func checkSQLCommand() *cobra.Command {
 		Short: "check the syntax of all SQL queries",
 		Run: func(cmd *cobra.Command, args []string) {
 			conf := config.Load()
-			db := mysql.MustConnect(conf.MySQL)
+			if repo.Dialect(conf) == repo.DialectMemory {
+				fmt.Println("Memory storage has no SQL queries")
+				return
+			}
+			db := repo.MustConnect(conf)
 
 			if len(args) > 0 {
 				filter := strings.Join(args, " ")
@@ -67,6 +78,110 @@ func checkSQLCommand() *cobra.Command {
 	}
 }
 
+func verifyCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "verify the integrity of the event log",
+		Run: func(cmd *cobra.Command, args []string) {
+			conf := config.Load()
+			logger := log.NewLogger(conf.Log)
+			if repo.Dialect(conf) != dblib.DialectMySQL {
+				fmt.Println("Event log verification is only supported on mysql")
+				return
+			}
+			db := repo.MustConnect(conf)
+
+			verifier := event.NewVerifier(conf, logger, db, event.NewArchiveStore(conf))
+			report, err := verifier.Verify(context.Background())
+			if err != nil {
+				panic(err)
+			}
+			verifier.LogReport(report)
+
+			_ = logger.Sync()
+			if !report.OK() {
+				os.Exit(1)
+			}
+		},
+	}
+}
+
+func archiveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "archive",
+		Short: "archive events satisfied the retention policy",
+		Run: func(cmd *cobra.Command, args []string) {
+			conf := config.Load()
+			logger := log.NewLogger(conf.Log)
+			store := event.NewArchiveStore(conf)
+			if store == nil {
+				fmt.Println("Event retention is disabled")
+				return
+			}
+			if repo.Dialect(conf) != dblib.DialectMySQL {
+				fmt.Println("Event retention is only supported on mysql")
+				return
+			}
+			db := repo.MustConnect(conf)
+
+			archiver := event.NewArchiver(conf, logger, db, store)
+			count, err := archiver.ArchiveOnce(context.Background())
+			if err != nil {
+				panic(err)
+			}
+
+			fmt.Println("Number of archived events:", count)
+		},
+	}
+}
+
+func rebuildProjectionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rebuild-projection",
+		Short: "rebuild the todo summary projection from the first event",
+		Run: func(cmd *cobra.Command, args []string) {
+			conf := config.Load()
+			if repo.Dialect(conf) != dblib.DialectMySQL {
+				fmt.Println("Todo summary projection is only supported on mysql")
+				return
+			}
+			db := repo.MustConnect(conf)
+
+			store := event.NewArchiveStore(conf)
+			events := event.NewCoreRepository(db, store)
+
+			checkpoint, err := event.NewProjector(db).Rebuild(context.Background(), events, 1000)
+			if err != nil {
+				panic(err)
+			}
+
+			fmt.Println("Rebuilt up to sequence:", checkpoint)
+		},
+	}
+}
+
+func backfillAggregateIDsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "backfill-aggregate-ids",
+		Short: "set the aggregate id of events inserted before the column existed",
+		Run: func(cmd *cobra.Command, args []string) {
+			conf := config.Load()
+			if repo.Dialect(conf) != dblib.DialectMySQL {
+				fmt.Println("Backfilling aggregate ids is only supported on mysql")
+				return
+			}
+			db := repo.MustConnect(conf)
+
+			count, err := repo.NewHistoryRepository(db).BackfillAggregateIDs(context.Background(), 1000)
+			if err != nil {
+				panic(err)
+			}
+
+			fmt.Println("Backfilled events:", count)
+		},
+	}
+}
+
 func startCommand() *cobra.Command {
 	return &cobra.Command{
 		Use:   "start",
@@ -79,7 +194,7 @@ func startCommand() *cobra.Command {
 
 func startEventServer() {
 	conf := config.Load()
-	root := event.NewRoot(conf)
+	root := event.NewRoot(conf, nil)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -2,16 +2,39 @@ package main
 
 import (
 	"fmt"
+	"github.com/spf13/cobra"
+	"todoapp/config"
+	"todoapp/lib/dblib"
+	"todoapp/lib/mysql"
+	"todoapp/lib/postgres"
+	"todoapp/lib/sqlite"
+	"todoapp/todoapp/repo"
+
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/golang-migrate/migrate/v4/database/mysql"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	"todoapp/config"
-	"todoapp/lib/mysql"
 )
 
 func main() {
 	conf := config.Load()
-	cmd := mysql.MigrateCommand(conf.MySQL.DSN())
+
+	var cmd *cobra.Command
+	switch repo.Dialect(conf) {
+	case dblib.DialectMySQL:
+		cmd = mysql.MigrateCommand(conf.MySQL.DSN())
+	case dblib.DialectSQLite:
+		cmd = sqlite.MigrateCommand(conf.SQLite)
+	case dblib.DialectPostgres:
+		cmd = postgres.MigrateCommand(conf.Postgres)
+	case repo.DialectMemory:
+		fmt.Println("Memory storage has no schema to migrate")
+		return
+	default:
+		panic("Invalid database driver")
+	}
+
 	err := cmd.Execute()
 	if err != nil {
 		fmt.Println("ERROR:", err)
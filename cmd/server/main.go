@@ -12,10 +12,12 @@ import (
 	"syscall"
 	"time"
 	"todoapp/config"
+	"todoapp/event"
 	"todoapp/lib/dblib"
 	"todoapp/lib/errors"
-	"todoapp/lib/mysql"
 	"todoapp/server"
+	"todoapp/todoapp/event/notify"
+	"todoapp/todoapp/repo"
 
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
@@ -24,6 +26,8 @@ import (
 	"google.golang.org/grpc"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 )
 
 func main() {
@@ -63,7 +67,11 @@ func checkSQLCommand() *cobra.Command {
 		Short: "check the syntax of all SQL queries",
 		Run: func(cmd *cobra.Command, args []string) {
 			conf := config.Load()
-			db := mysql.MustConnect(conf.MySQL)
+			if repo.Dialect(conf) == repo.DialectMemory {
+				fmt.Println("Memory storage has no SQL queries")
+				return
+			}
+			db := repo.MustConnect(conf)
 
 			if len(args) > 0 {
 				filter := strings.Join(args, " ")
@@ -83,7 +91,16 @@ func checkSQLCommand() *cobra.Command {
 
 func startServer() {
 	conf := config.Load()
-	root := server.NewRoot(conf)
+
+	// with the channel notifier the event core runs in this process, signalled without RPCs
+	var eventRoot *event.Root
+	var channel *notify.Channel
+	if conf.Event.Notifier.Type == "channel" {
+		channel = notify.NewChannel()
+		eventRoot = event.NewRoot(conf, channel)
+	}
+
+	root := server.NewRoot(conf, channel)
 
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -113,11 +130,24 @@ func startServer() {
 		Handler: httpMux,
 	}
 
+	ctx = context.Background()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
 	//--------------------------------
 	// Run HTTP & gRPC servers
 	//--------------------------------
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(3)
+
+	if eventRoot != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			eventRoot.Run(ctx)
+		}()
+	}
 
 	go func() {
 		defer wg.Done()
@@ -142,22 +172,32 @@ func startServer() {
 		}
 	}()
 
+	go func() {
+		defer wg.Done()
+
+		root.Run(ctx)
+	}()
+
 	//--------------------------------
 	// Graceful Shutdown
 	//--------------------------------
 	<-stop
 
 	ctx = context.Background()
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
+	ctx, shutdownCancel := context.WithTimeout(ctx, 30*time.Second)
+	defer shutdownCancel()
 
 	grpcServer.GracefulStop()
 	err := httpServer.Shutdown(ctx)
 	if err != nil {
 		panic(err)
 	}
+	cancel()
 
 	wg.Wait()
 
+	if eventRoot != nil {
+		eventRoot.Shutdown()
+	}
 	root.Shutdown()
 }
@@ -5,14 +5,26 @@ import (
 	"github.com/spf13/viper"
 	"todoapp/lib/log"
 	"todoapp/lib/mysql"
+	"todoapp/lib/postgres"
+	"todoapp/lib/sqlite"
 )
 
+// Database for selecting the storage backend
+type Database struct {
+	// mysql, sqlite3 or postgres, the name of the database/sql driver,
+	// or memory for keeping everything in the API server process, needs the channel event notifier
+	Driver string `mapstructure:"driver"`
+}
+
 // Config for configuring whole app
 type Config struct {
-	Server Server       `mapstructure:"server"`
-	Event  Event        `mapstructure:"event"`
-	Log    log.Config   `mapstructure:"log"`
-	MySQL  mysql.Config `mapstructure:"mysql"`
+	Server   Server          `mapstructure:"server"`
+	Event    Event           `mapstructure:"event"`
+	Log      log.Config      `mapstructure:"log"`
+	Database Database        `mapstructure:"database"`
+	MySQL    mysql.Config    `mapstructure:"mysql"`
+	SQLite   sqlite.Config   `mapstructure:"sqlite"`
+	Postgres postgres.Config `mapstructure:"postgres"`
 }
 
 // Load config from config.yml
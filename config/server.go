@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ServerListen for http & grpc hostname
 type ServerListen struct {
@@ -16,10 +19,74 @@ func (s ServerListen) String() string {
 type Server struct {
 	GRPC ServerListen `mapstructure:"grpc"`
 	HTTP ServerListen `mapstructure:"http"`
+	// serving TodoService.List from the todo summary projection, mysql only
+	ListFromProjection bool `mapstructure:"list_from_projection"`
+}
+
+// EventVerify for event log verifier configure, the verifier only runs on mysql with a single partition
+type EventVerify struct {
+	Interval   time.Duration `mapstructure:"interval"`
+	StaleAfter time.Duration `mapstructure:"stale_after"`
+}
+
+// EventRetention for event retention configure
+type EventRetention struct {
+	Enabled    bool          `mapstructure:"enabled"`
+	ArchiveDir string        `mapstructure:"archive_dir"`
+	MaxAge     time.Duration `mapstructure:"max_age"`
+	Interval   time.Duration `mapstructure:"interval"`
+	BatchSize  uint64        `mapstructure:"batch_size"`
+}
+
+// EventCompression for compression of stored event payloads
+type EventCompression struct {
+	Codec     string `mapstructure:"codec"`
+	Threshold int    `mapstructure:"threshold"`
+}
+
+// EventNotifier for change notification source configure
+type EventNotifier struct {
+	// signal: only the Signal RPC from the API server, binlog: also reading the MySQL binlog,
+	// channel: the event core runs inside the API server, signalled in-process
+	Type     string `mapstructure:"type"`
+	ServerID uint32 `mapstructure:"server_id"`
+}
+
+// EventProjector for read model projector configure
+type EventProjector struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+// EventStartup for catching up of the event core when starting
+type EventStartup struct {
+	// last events loaded into the ring buffer, zero for the size of the ring buffer
+	Limit uint64 `mapstructure:"limit"`
+	// pages of events read concurrently by a lagging publisher, zero for the default
+	PrefetchDepth int `mapstructure:"prefetch_depth"`
 }
 
 // Event for event server configure
 type Event struct {
-	GRPC ServerListen `mapstructure:"grpc"`
-	HTTP ServerListen `mapstructure:"http"`
+	GRPC        ServerListen     `mapstructure:"grpc"`
+	HTTP        ServerListen     `mapstructure:"http"`
+	Verify      EventVerify      `mapstructure:"verify"`
+	Retention   EventRetention   `mapstructure:"retention"`
+	Compression EventCompression `mapstructure:"compression"`
+	Notifier    EventNotifier    `mapstructure:"notifier"`
+	Projector   EventProjector   `mapstructure:"projector"`
+	Startup     EventStartup     `mapstructure:"startup"`
+	// waiting for publishers to finish their batches on shutdown
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+	// zero or one for a single partition, the count is stored on the first start and must not change without a migration,
+	// mysql only, more than one partition is not supported with the binlog notifier, the projector and the retention,
+	// and disables the event log verifier
+	Partitions uint32 `mapstructure:"partitions"`
+}
+
+// PartitionCount returns the number of partitions of the events, at least one
+func (e Event) PartitionCount() uint32 {
+	if e.Partitions == 0 {
+		return 1
+	}
+	return e.Partitions
 }
@@ -3,12 +3,19 @@ package event
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
 	"todoapp/config"
+	"todoapp/lib/dblib"
 	"todoapp/lib/errors"
 	"todoapp/lib/log"
+	"todoapp/todoapp/event/archive"
 	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/event/notify"
+	"todoapp/todoapp/event/partition"
+	"todoapp/todoapp/event/projector"
+	"todoapp/todoapp/event/verify"
 	"todoapp/todoapp/repo"
 	"todoapp/todoapp/server"
 
@@ -31,19 +38,27 @@ type Root struct {
 	logger *zap.Logger
 	db     *sqlx.DB
 
-	todoCore   *core.Core
-	todoServer *server.EventServer
+	todoCore     *partition.Group
+	todoServer   *server.EventServer
+	todoVerifier *verify.Verifier
+	todoArchiver *archive.Archiver
 
 	health *common_server.HealthServer
 }
 
+// the IDs of the publishers of the todo events, MUST NOT change, their sequences are saved by ID
+const (
+	printPublisherID       core.PublisherID = 1
+	todoSummaryPublisherID core.PublisherID = 2
+)
+
 type publisher struct {
 }
 
 var _ core.Publisher = &publisher{}
 
 func (p *publisher) GetID() core.PublisherID {
-	return 1
+	return printPublisherID
 }
 
 func (p *publisher) Publish(events []core.Event) error {
@@ -51,21 +66,185 @@ func (p *publisher) Publish(events []core.Event) error {
 	return nil
 }
 
-// NewRoot ...
-func NewRoot(conf config.Config) *Root {
+func newPublishers(conf config.Config, db *sqlx.DB) []core.Publisher {
+	publishers := []core.Publisher{
+		&publisher{},
+	}
+	if conf.Event.Projector.Enabled {
+		publishers = append(publishers, NewProjector(db))
+	}
+	return publishers
+}
+
+// NewProjector creates the projector of the todo summary projection
+func NewProjector(db *sqlx.DB) *projector.Projector {
+	return projector.NewProjector(todoSummaryPublisherID, repo.NewProjectionRepository(db), projector.TodoSummaryProjection{})
+}
+
+// NewVerifier creates the event log verifier, the log is checked to continue after the archive when store is not nil
+func NewVerifier(conf config.Config, logger *zap.Logger, db *sqlx.DB, store *archive.Store) *verify.Verifier {
+	var options []verify.Option
+	if conf.Event.Verify.Interval > 0 {
+		options = append(options, verify.WithInterval(conf.Event.Verify.Interval))
+	}
+	if conf.Event.Verify.StaleAfter > 0 {
+		options = append(options, verify.WithStaleAfter(conf.Event.Verify.StaleAfter))
+	}
+	if store != nil {
+		options = append(options, verify.WithArchivedSequence(store.LastSequence))
+	}
+	return verify.NewVerifier(repo.NewVerifyRepository(db), logger, options...)
+}
+
+// NewArchiveStore creates the store of archived events, returns nil when retention is disabled
+func NewArchiveStore(conf config.Config) *archive.Store {
+	if !conf.Event.Retention.Enabled {
+		return nil
+	}
+
+	store, err := archive.NewStore(conf.Event.Retention.ArchiveDir)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+// NewArchiver creates the archiver applying the retention policy
+func NewArchiver(conf config.Config, logger *zap.Logger, db *sqlx.DB, store *archive.Store) *archive.Archiver {
+	var publisherIDs []core.PublisherID
+	for _, p := range newPublishers(conf, db) {
+		publisherIDs = append(publisherIDs, p.GetID())
+	}
+
+	retention := conf.Event.Retention
+	options := []archive.Option{
+		archive.WithMaxAge(retention.MaxAge),
+	}
+	if retention.Interval > 0 {
+		options = append(options, archive.WithInterval(retention.Interval))
+	}
+	if retention.BatchSize > 0 {
+		options = append(options, archive.WithBatchSize(retention.BatchSize))
+	}
+
+	return archive.NewArchiver(repo.NewRetentionRepository(db), store, logger, publisherIDs, options...)
+}
+
+// NewCoreRepository creates the repository of the event core, reading archived events when store is not nil
+func NewCoreRepository(db *sqlx.DB, store *archive.Store) core.Repository {
+	if store != nil {
+		return repo.NewArchivedEventRepository(db, store)
+	}
+	return repo.NewCoreRepository(db)
+}
+
+// checkPartitionsSupported panics on the features not working with partitioned events,
+// they all rely on a single sequence
+func checkPartitionsSupported(conf config.Config, dialect dblib.Dialect) {
+	if dialect != dblib.DialectMySQL {
+		panic("Event partitions are only supported on mysql")
+	}
+	if conf.Event.Notifier.Type == "binlog" {
+		panic("Binlog event notifier is not supported with event partitions")
+	}
+	if conf.Event.Projector.Enabled {
+		panic("Todo summary projection is not supported with event partitions")
+	}
+	if conf.Event.Retention.Enabled {
+		panic("Event retention is not supported with event partitions")
+	}
+}
+
+// NewRoot creates the event root, channel is the in-process notifier of the channel notifier type,
+// nil when not running inside the API server
+func NewRoot(conf config.Config, channel *notify.Channel) *Root {
 	logger := log.NewLogger(conf.Log)
-	db := sqlx.MustConnect("mysql", conf.MySQL.DSN())
+	db := repo.MustConnect(conf)
 
-	todoRepo := repo.NewEventRepository(db)
-	todoCore := core.NewCore(todoRepo,
-		core.SetSequenceImpl, core.GetSequenceImpl,
-		core.WithErrorTimeout(10*time.Second),
-		core.AddPublisher(&publisher{}),
+	options := []core.Option{
+		core.WithErrorTimeout(10 * time.Second),
 		core.WithErrorLogger(func(message string, err error) {
 			logger.WithOptions(zap.AddCallerSkip(1)).
 				Error(message, zap.Error(err))
 		}),
-	)
+	}
+	for _, p := range newPublishers(conf, db) {
+		options = append(options, core.AddPublisher(p))
+	}
+	if conf.Event.Startup.Limit > 0 {
+		options = append(options, core.WithStartupLimit(conf.Event.Startup.Limit))
+	}
+	if conf.Event.DrainTimeout > 0 {
+		options = append(options, core.WithDrainTimeout(conf.Event.DrainTimeout))
+	}
+	if conf.Event.Startup.PrefetchDepth > 0 {
+		options = append(options, core.WithPrefetchDepth(conf.Event.Startup.PrefetchDepth))
+	}
+
+	dialect := repo.Dialect(conf)
+	partitions := conf.Event.PartitionCount()
+	if partitions > 1 {
+		checkPartitionsSupported(conf, dialect)
+	}
+	if dialect == dblib.DialectMySQL {
+		err := repo.CheckPartitionCount(db, partitions)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	switch conf.Event.Notifier.Type {
+	case "", "signal":
+	case "binlog":
+		if dialect != dblib.DialectMySQL {
+			panic("Binlog event notifier is only supported on mysql")
+		}
+		binlog := notify.NewBinlog(conf.MySQL, conf.Event.Notifier.ServerID, "todo_events")
+		options = append(options, core.AddNotifier(binlog))
+	case "channel":
+		if channel == nil {
+			panic("Channel event notifier needs the event core running in the API server")
+		}
+		options = append(options, core.AddNotifier(channel))
+	default:
+		panic("Invalid event notifier type")
+	}
+	if dialect == repo.DialectMemory && channel == nil {
+		panic("Memory storage needs the event core running in the API server")
+	}
+
+	if conf.Event.Projector.Enabled && dialect != dblib.DialectMySQL {
+		panic("Todo summary projection is only supported on mysql")
+	}
+
+	var todoArchiver *archive.Archiver
+	var todoVerifier *verify.Verifier
+
+	store := NewArchiveStore(conf)
+	if store != nil {
+		if dialect != dblib.DialectMySQL {
+			panic("Event retention is only supported on mysql")
+		}
+		todoArchiver = NewArchiver(conf, logger, db, store)
+	}
+	todoRepo := NewCoreRepository(db, store)
+
+	// the queries of the verifier are only written for mysql and a single sequence
+	switch {
+	case dialect != dblib.DialectMySQL:
+		logger.Warn("Event log verifier is disabled, it is only supported on mysql", zap.String("dialect", string(dialect)))
+	case partitions > 1:
+		logger.Warn("Event log verifier is disabled with event partitions", zap.Uint32("partitions", partitions))
+	default:
+		todoVerifier = NewVerifier(conf, logger, db, store)
+	}
+
+	todoCore := partition.NewGroup(partitions, func(p uint32) core.Repository {
+		if partitions == 1 {
+			return todoRepo
+		}
+		return repo.NewPartitionEventRepository(db, p, partitions)
+	}, options...)
 
 	todoCore.Signal()
 
@@ -76,8 +255,10 @@ func NewRoot(conf config.Config) *Root {
 		logger: logger,
 		db:     db,
 
-		todoCore:   todoCore,
-		todoServer: todoServer,
+		todoCore:     todoCore,
+		todoServer:   todoServer,
+		todoVerifier: todoVerifier,
+		todoArchiver: todoArchiver,
 
 		health: &common_server.HealthServer{},
 	}
@@ -126,13 +307,50 @@ func (r *Root) Register(
 
 // Run ...
 func (r *Root) Run(ctx context.Context) {
-	r.todoCore.Run(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+
+		r.todoCore.Run(ctx)
+	}()
+
+	if r.todoVerifier != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r.todoVerifier.Run(ctx)
+		}()
+	}
+
+	if r.todoArchiver != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			r.todoArchiver.Run(ctx)
+		}()
+	}
+
+	wg.Wait()
 }
 
-// Shutdown for graceful shutdown
+// Shutdown for graceful shutdown, after Run returned
+// reports the events left pending for each publisher
 func (r *Root) Shutdown() {
-	if err := r.db.Close(); err != nil {
-		panic(err)
+	for id, count := range r.todoCore.Pending() {
+		r.logger.Info("Events left pending",
+			zap.Uint32("publisher_id", uint32(id)),
+			zap.Uint64("count", count),
+		)
+	}
+
+	if r.db != nil {
+		if err := r.db.Close(); err != nil {
+			panic(err)
+		}
 	}
 
 	r.logger.Info("Graceful shutdown completed")
@@ -3,8 +3,6 @@ module todoapp
 go 1.14
 
 require (
-	github.com/QuangTung97/eventcore v0.0.0-20201223032558-ac66529b0de0
-	github.com/cheekybits/genny v1.0.0
 	github.com/go-sql-driver/mysql v1.5.0
 	github.com/golang-migrate/migrate/v4 v4.14.1
 	github.com/golang/mock v1.4.4
@@ -15,8 +13,11 @@ require (
 	github.com/jmoiron/sqlx v1.2.0
 	github.com/kisielk/errcheck v1.2.0
 	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/lib/pq v1.8.0
+	github.com/mattn/go-sqlite3 v1.10.0
 	github.com/prometheus/client_golang v0.9.3
 	github.com/sahilm/fuzzy v0.1.0
+	github.com/siddontang/go-mysql v1.1.0
 	github.com/spf13/cobra v1.1.1
 	github.com/spf13/viper v1.7.0
 	github.com/stretchr/testify v1.6.1
@@ -25,12 +26,13 @@ require (
 	golang.org/x/lint v0.0.0-20200302205851-738671d3881b
 	golang.org/x/net v0.0.0-20201209123823-ac852fbbde11 // indirect
 	golang.org/x/sys v0.0.0-20201207223542-d4d67f95c62d // indirect
-	golang.org/x/text v0.3.4 // indirect
-	google.golang.org/genproto v0.0.0-20201209185603-f92720507ed4 // indirect
+	golang.org/x/text v0.3.4
+	golang.org/x/tools v0.0.0-20200825202427-b303f430e36d
+	google.golang.org/genproto v0.0.0-20201209185603-f92720507ed4
 	google.golang.org/grpc v1.34.0
 	google.golang.org/protobuf v1.25.0
 	gopkg.in/yaml.v2 v2.4.0
-	todoapp-rpc v0.0.0
+	todoapp-rpc v0.1.0
 )
 
-replace todoapp-rpc v0.0.0 => ../todoapp-rpc/
+replace todoapp-rpc v0.1.0 => ../todoapp-rpc/
@@ -9,62 +9,116 @@ import (
 	"sync/atomic"
 )
 
+// Dialect the SQL dialect of a query, equals to the name of the database/sql driver
+type Dialect string
+
+const (
+	// DialectMySQL ...
+	DialectMySQL Dialect = "mysql"
+	// DialectSQLite ...
+	DialectSQLite Dialect = "sqlite3"
+	// DialectPostgres ...
+	DialectPostgres Dialect = "postgres"
+)
+
 type registeredQuery struct {
-	file  string
-	line  int
-	query string
+	file    string
+	line    int
+	query   string
+	dialect Dialect
 }
 
 type registeredNamedQuery struct {
-	file  string
-	line  int
-	query string
+	file    string
+	line    int
+	query   string
+	dialect Dialect
 }
 
 var disabledRegistering int32 = 0
 var registeredQueries []registeredQuery
 var registeredNamedQueries []registeredNamedQuery
 
-// NewQuery registers a sqlx query
+// NewQuery registers a MySQL sqlx query
 func NewQuery(q string) string {
+	return newQuery(DialectMySQL, q)
+}
+
+// NewDialectQuery registers a sqlx query of the dialect
+func NewDialectQuery(dialect Dialect, q string) string {
+	return newQuery(dialect, q)
+}
+
+func newQuery(dialect Dialect, q string) string {
 	if atomic.LoadInt32(&disabledRegistering) != 0 {
 		panic("Can NOT use NewQuery inside functions")
 	}
 
 	q = strings.TrimSpace(q)
-	_, file, line, ok := runtime.Caller(1)
+	_, file, line, ok := runtime.Caller(2)
 	if !ok {
 		panic("can't get caller info")
 	}
 
 	registeredQueries = append(registeredQueries, registeredQuery{
-		file:  file,
-		line:  line,
-		query: q,
+		file:    file,
+		line:    line,
+		query:   q,
+		dialect: dialect,
 	})
 	return q
 }
 
-// NewNamedQuery registers a named sqlx query
+// NewNamedQuery registers a named MySQL sqlx query
 func NewNamedQuery(q string) string {
+	return newNamedQuery(DialectMySQL, q)
+}
+
+// NewDialectNamedQuery registers a named sqlx query of the dialect
+func NewDialectNamedQuery(dialect Dialect, q string) string {
+	return newNamedQuery(dialect, q)
+}
+
+func newNamedQuery(dialect Dialect, q string) string {
 	if atomic.LoadInt32(&disabledRegistering) != 0 {
 		panic("Can NOT use NewNamedQuery inside functions")
 	}
 
 	q = strings.TrimSpace(q)
-	_, file, line, ok := runtime.Caller(1)
+	_, file, line, ok := runtime.Caller(2)
 	if !ok {
 		panic("can't get caller info")
 	}
 
 	registeredNamedQueries = append(registeredNamedQueries, registeredNamedQuery{
-		file:  file,
-		line:  line,
-		query: q,
+		file:    file,
+		line:    line,
+		query:   q,
+		dialect: dialect,
 	})
 	return q
 }
 
+func dialectQueries(dialect Dialect) []registeredQuery {
+	var result []registeredQuery
+	for _, q := range registeredQueries {
+		if q.dialect == dialect {
+			result = append(result, q)
+		}
+	}
+	return result
+}
+
+func dialectNamedQueries(dialect Dialect) []registeredNamedQuery {
+	var result []registeredNamedQuery
+	for _, q := range registeredNamedQueries {
+		if q.dialect == dialect {
+			result = append(result, q)
+		}
+	}
+	return result
+}
+
 type checkResult struct {
 	query registeredQuery
 	err   error
@@ -76,12 +130,12 @@ type namedCheckResult struct {
 }
 
 func checkNormalQueries(db *sqlx.DB, filter string, colorHighlight string, colorNone string) []checkResult {
-	result := make([]checkResult, 0, len(registeredQueries))
+	queries := dialectQueries(Dialect(db.DriverName()))
+	result := make([]checkResult, 0, len(queries))
 
-	queries := registeredQueries
 	var highlights []string
 	if filter != "" {
-		queries, highlights = fuzzyMatchNormal(registeredQueries, filter, colorHighlight, colorNone)
+		queries, highlights = fuzzyMatchNormal(queries, filter, colorHighlight, colorNone)
 	}
 
 	for i, q := range queries {
@@ -107,12 +161,12 @@ func checkNormalQueries(db *sqlx.DB, filter string, colorHighlight string, color
 }
 
 func checkNamedQueries(db *sqlx.DB, filter string, colorHighlight, colorNone string) []namedCheckResult {
-	result := make([]namedCheckResult, 0, len(registeredNamedQueries))
+	queries := dialectNamedQueries(Dialect(db.DriverName()))
+	result := make([]namedCheckResult, 0, len(queries))
 
-	queries := registeredNamedQueries
 	var highlights []string
 	if filter != "" {
-		queries, highlights = fuzzyMatchNamed(registeredNamedQueries, filter, colorHighlight, colorNone)
+		queries, highlights = fuzzyMatchNamed(queries, filter, colorHighlight, colorNone)
 	}
 
 	for i, q := range queries {
@@ -181,7 +235,7 @@ func FinishRegisterQueries() {
 	atomic.StoreInt32(&disabledRegistering, 1)
 }
 
-// CheckQueries validates syntax of all registered queries
+// CheckQueries validates syntax of all registered queries of the dialect of db
 func CheckQueries(db *sqlx.DB, opts CheckOptions) {
 	colorRed := ColorRed
 	colorOrange := ColorOrange
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Is reports whether target is an *Error with the same code, for errors.Is
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Code == e.Code
+}
+
+// GRPCStatus returns the status of ToRPCError, so status.Code and status.FromError
+// keep working on errors converted by UnaryClientInterceptor
+func (e *Error) GRPCStatus() *status.Status {
+	return status.Convert(e.ToRPCError())
+}
+
+// FromError returns the *Error in the chain of err, or converted from a gRPC status error
+func FromError(err error) (*Error, bool) {
+	var domainErr *Error
+	if stderrors.As(err, &domainErr) {
+		return domainErr, true
+	}
+	return FromRPCError(err)
+}
+
+// UnaryClientInterceptor converts grpc status errors of domain errors back to *Error,
+// so callers can match them with errors.Is and errors.As
+func UnaryClientInterceptor(
+	ctx context.Context, method string, req, reply interface{},
+	cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	if err == nil {
+		return nil
+	}
+
+	domainErr, ok := FromRPCError(err)
+	if !ok {
+		return err
+	}
+	return domainErr
+}
@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestError_Is(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Not found todo {todoId}",
+		Details: map[string]interface{}{
+			"todoId": int64(12),
+		},
+	}
+	wrapped := fmt.Errorf("get todo: %w", e)
+
+	assert.True(t, stderrors.Is(wrapped, &Error{RPCStatus: 5, Code: "0501"}))
+	assert.False(t, stderrors.Is(wrapped, &Error{RPCStatus: 5, Code: "0502"}))
+	assert.False(t, stderrors.Is(wrapped, stderrors.New("some error")))
+}
+
+func TestError_GRPCStatus(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Not found todo {todoId}",
+		Details: map[string]interface{}{
+			"todoId": int64(12),
+		},
+	}
+
+	assert.Equal(t, codes.NotFound, status.Code(e))
+	assert.Equal(t, "Not found todo 12", status.Convert(e).Message())
+}
+
+func TestFromError(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Not found todo {todoId}",
+		Details: map[string]interface{}{
+			"todoId": int64(12),
+		},
+	}
+
+	table := []struct {
+		name     string
+		err      error
+		expected *Error
+		ok       bool
+	}{
+		{
+			name:     "domain error",
+			err:      fmt.Errorf("get todo: %w", e),
+			expected: e,
+			ok:       true,
+		},
+		{
+			name: "status error",
+			err:  e.ToRPCError(),
+			expected: &Error{
+				RPCStatus: 5,
+				Code:      "0501",
+				Message:   "Not found todo 12",
+				Details:   map[string]interface{}{"todoId": int64(12)},
+			},
+			ok: true,
+		},
+		{
+			name: "other status error",
+			err:  status.Error(codes.Internal, "internal"),
+		},
+		{
+			name: "other error",
+			err:  stderrors.New("some error"),
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			result, ok := FromError(e.err)
+			assert.Equal(t, e.ok, ok)
+			assert.Equal(t, e.expected, result)
+		})
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	invoke := func(err error) error {
+		return UnaryClientInterceptor(context.Background(), "/Service/Method", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return err
+			},
+		)
+	}
+
+	assert.Nil(t, invoke(nil))
+
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Not found todo {todoId}",
+		Details: map[string]interface{}{
+			"todoId": int64(12),
+		},
+	}
+
+	err := invoke(e.ToRPCError())
+	var domainErr *Error
+	assert.True(t, stderrors.As(err, &domainErr))
+	assert.True(t, stderrors.Is(err, e))
+	assert.Equal(t, "Not found todo 12", domainErr.Message)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	statusErr := status.Error(codes.Unavailable, "unavailable")
+	assert.Equal(t, statusErr, invoke(statusErr))
+}
@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/status"
 	"time"
 
@@ -20,16 +23,33 @@ type Error struct {
 	RPCStatus uint32
 	Code      string
 	Message   string
-	Details   map[string]interface{}
+	// message templates by locale, in addition to Message in DefaultLocale
+	Messages map[string]string
+	Details  map[string]interface{}
+	// invalid fields of the request, sent as a google.rpc.BadRequest
+	Violations []FieldViolation
+	// whether the call can be retried, after RetryAfter when not zero, sent as a google.rpc.RetryInfo
+	Retryable  bool
+	RetryAfter time.Duration
+	// the error causing this one and the stack where it was wrapped, never sent to clients
+	Cause error
+	stack []uintptr
 }
 
 var _ error = &Error{}
 
 func (e *Error) Error() string {
-	return fmt.Sprintf(
+	s := fmt.Sprintf(
 		"rpc status: %v, code: %s, message: %s, details: %v",
 		codes.Code(e.RPCStatus), e.Code, e.Message, e.Details,
 	)
+	if len(e.Violations) > 0 {
+		s += fmt.Sprintf(", violations: %v", e.Violations)
+	}
+	if e.Cause != nil {
+		s += fmt.Sprintf(", cause: %v", e.Cause)
+	}
+	return s
 }
 
 // WithDetail return a new error with detail
@@ -40,12 +60,9 @@ func (e *Error) WithDetail(field string, value interface{}) *Error {
 	}
 	details[field] = value
 
-	return &Error{
-		RPCStatus: e.RPCStatus,
-		Code:      e.Code,
-		Message:   e.Message,
-		Details:   details,
-	}
+	result := *e
+	result.Details = details
+	return &result
 }
 
 func fieldValueToDetail(field string, value interface{}) (proto.Message, error) {
@@ -59,13 +76,31 @@ func fieldValueToDetail(field string, value interface{}) (proto.Message, error)
 	case int64:
 		return &ErrorDetailInt64{Field: field, Value: v}, nil
 
+	case uint64:
+		return &ErrorDetailUint64{Field: field, Value: v}, nil
+
+	case int32:
+		return &ErrorDetailInt32{Field: field, Value: v}, nil
+
+	case uint32:
+		return &ErrorDetailUint32{Field: field, Value: v}, nil
+
 	case float64:
 		return &ErrorDetailDouble{Field: field, Value: v}, nil
 
+	case []string:
+		return &ErrorDetailStringList{Field: field, Values: v}, nil
+
+	case []int64:
+		return &ErrorDetailInt64List{Field: field, Values: v}, nil
+
 	case time.Time:
 		t, _ := ptypes.TimestampProto(v)
 		return &ErrorDetailTimestamp{Field: field, Value: t}, nil
 
+	case time.Duration:
+		return &ErrorDetailDuration{Field: field, Value: ptypes.DurationProto(v)}, nil
+
 	default:
 		return nil, stderrors.New("unrecognized error detail type")
 	}
@@ -82,9 +117,24 @@ func detailToFieldValue(detail interface{}) (string, interface{}, error) {
 	case *ErrorDetailInt64:
 		return d.Field, d.Value, nil
 
+	case *ErrorDetailUint64:
+		return d.Field, d.Value, nil
+
+	case *ErrorDetailInt32:
+		return d.Field, d.Value, nil
+
+	case *ErrorDetailUint32:
+		return d.Field, d.Value, nil
+
 	case *ErrorDetailDouble:
 		return d.Field, d.Value, nil
 
+	case *ErrorDetailStringList:
+		return d.Field, d.Values, nil
+
+	case *ErrorDetailInt64List:
+		return d.Field, d.Values, nil
+
 	case *ErrorDetailTimestamp:
 		t, err := ptypes.Timestamp(d.Value)
 		if err != nil {
@@ -92,6 +142,13 @@ func detailToFieldValue(detail interface{}) (string, interface{}, error) {
 		}
 		return d.Field, t, nil
 
+	case *ErrorDetailDuration:
+		v, err := ptypes.Duration(d.Value)
+		if err != nil {
+			return "", nil, err
+		}
+		return d.Field, v, nil
+
 	default:
 		return "", nil, stderrors.New("unrecognized interface type")
 	}
@@ -99,7 +156,13 @@ func detailToFieldValue(detail interface{}) (string, interface{}, error) {
 
 // ToRPCError converts Error to grpc status error
 func (e *Error) ToRPCError() error {
-	st := status.New(codes.Code(e.RPCStatus), e.Message)
+	return e.ToLocalizedRPCError("")
+}
+
+// ToLocalizedRPCError converts Error to grpc status error, adding a google.rpc.LocalizedMessage
+// when a message exists for the locale best matching acceptLanguage
+func (e *Error) ToLocalizedRPCError(acceptLanguage string) error {
+	st := status.New(codes.Code(e.RPCStatus), e.FormatMessage())
 
 	var details []proto.Message
 
@@ -118,6 +181,24 @@ func (e *Error) ToRPCError() error {
 		details = append(details, detail)
 	}
 
+	if len(e.Violations) > 0 {
+		details = append(details, violationsToBadRequest(e.Violations))
+	}
+
+	if e.Retryable {
+		details = append(details, &errdetails.RetryInfo{
+			RetryDelay: ptypes.DurationProto(e.RetryAfter),
+		})
+	}
+
+	locale := e.SelectLocale(acceptLanguage)
+	if locale != DefaultLocale {
+		details = append(details, &errdetails.LocalizedMessage{
+			Locale:  locale,
+			Message: e.formatTemplate(e.LocalizedMessage(locale)),
+		})
+	}
+
 	st, err := st.WithDetails(details...)
 	if err != nil {
 		return err
@@ -151,8 +232,31 @@ func FromRPCStatus(st *status.Status) (*Error, bool) {
 		return nil, false
 	}
 
+	var messages map[string]string
+	var violations []FieldViolation
+	var retryable bool
+	var retryAfter time.Duration
 	detailMap := make(map[string]interface{})
 	for _, detail := range details[1:] {
+		switch d := detail.(type) {
+		case *errdetails.LocalizedMessage:
+			messages = map[string]string{d.Locale: d.Message}
+			continue
+
+		case *errdetails.BadRequest:
+			violations = badRequestToViolations(d)
+			continue
+
+		case *errdetails.RetryInfo:
+			delay, err := ptypes.Duration(d.RetryDelay)
+			if err != nil {
+				return nil, false
+			}
+			retryable = true
+			retryAfter = delay
+			continue
+		}
+
 		field, value, err := detailToFieldValue(detail)
 		if err != nil {
 			return nil, false
@@ -161,14 +265,19 @@ func FromRPCStatus(st *status.Status) (*Error, bool) {
 	}
 
 	return &Error{
-		RPCStatus: uint32(st.Code()),
-		Code:      code.Value,
-		Message:   st.Message(),
-		Details:   detailMap,
+		RPCStatus:  uint32(st.Code()),
+		Code:       code.Value,
+		Message:    st.Message(),
+		Messages:   messages,
+		Details:    detailMap,
+		Violations: violations,
+		Retryable:  retryable,
+		RetryAfter: retryAfter,
 	}, true
 }
 
-// UnaryServerInterceptor converts domain error to grpc status error
+// UnaryServerInterceptor converts domain error to grpc status error,
+// logging the errors with a cause and the errors not known to the client with the logger of ctx
 func UnaryServerInterceptor(
 	ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
 ) (interface{}, error) {
@@ -176,7 +285,12 @@ func UnaryServerInterceptor(
 	if err != nil {
 		var domainErr *Error
 		if stderrors.As(err, &domainErr) {
-			return nil, domainErr.ToRPCError()
+			if domainErr.Cause != nil {
+				ctxzap.Extract(ctx).Error("Domain error",
+					zap.Error(err), zap.String("stack", domainErr.StackTrace()),
+				)
+			}
+			return nil, domainErr.ToLocalizedRPCError(AcceptLanguageFromContext(ctx))
 		}
 
 		st, ok := status.FromError(err)
@@ -184,6 +298,7 @@ func UnaryServerInterceptor(
 			return nil, st.Err()
 		}
 
+		ctxzap.Extract(ctx).Error("Unknown error", zap.Error(err))
 		st = status.New(codes.Unknown, err.Error())
 		return nil, st.Err()
 	}
@@ -55,6 +55,26 @@ func TestError_ToRPCError_Normal(t *testing.T) {
 	assert.Equal(t, e, e1)
 }
 
+func TestError_ToRPCError_DetailTypes(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0502",
+		Message:   "Not found todo items",
+		Details: map[string]interface{}{
+			"todoId":    uint32(12),
+			"ownerId":   uint64(1 << 60),
+			"priority":  int32(-1),
+			"tags":      []string{"work", "urgent"},
+			"itemIds":   []int64{3, 5},
+			"expiredIn": 90 * time.Second,
+		},
+	}
+
+	e1, ok := FromRPCError(e.ToRPCError())
+	assert.True(t, ok)
+	assert.Equal(t, e, e1)
+}
+
 func TestError_ToRPCError_Details(t *testing.T) {
 	table := []struct {
 		name           string
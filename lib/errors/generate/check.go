@@ -0,0 +1,184 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const (
+	// GeneratedPackagePath the import path of the package generated by Generate
+	GeneratedPackagePath = "todoapp/pkg/errors"
+	libErrorsPackagePath = "todoapp/lib/errors"
+)
+
+// CheckLoadMode the packages.LoadMode needed by CheckUsages,
+// the usages are found from the syntax only, without type checking the module
+const CheckLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax
+
+// CheckIssue a problem of the error catalog found by CheckUsages or CheckDrift
+type CheckIssue struct {
+	Position string
+	Message  string
+}
+
+func (i CheckIssue) String() string {
+	if i.Position == "" {
+		return i.Message
+	}
+	return i.Position + ": " + i.Message
+}
+
+// importNames returns the names of the imports of path in file
+func importNames(file *ast.File, path string) map[string]struct{} {
+	result := make(map[string]struct{})
+	for _, spec := range file.Imports {
+		p, err := strconv.Unquote(spec.Path.Value)
+		if err != nil || p != path {
+			continue
+		}
+
+		name := path[strings.LastIndex(path, "/")+1:]
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		result[name] = struct{}{}
+	}
+	return result
+}
+
+func isPackageIdent(expr ast.Expr, names map[string]struct{}) bool {
+	ident, ok := expr.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	_, existed := names[ident.Name]
+	return existed
+}
+
+func isGeneratedFile(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			return false
+		}
+		if strings.HasPrefix(group.Text(), "Code generated") {
+			return true
+		}
+	}
+	return false
+}
+
+// collectUsages adds to used the errors referenced in file, like errors.Todo.NotFoundTodo,
+// errors.NewErrTodoNotFoundTodo or errors.ErrTodoNotFoundTodo,
+// and returns the liberrors.Error composite literals,
+// tagNames are the tag variables referenced without package name in the generated package
+func collectUsages(file *ast.File, tagNames map[string]struct{}, used map[string]struct{}) []*ast.CompositeLit {
+	generatedNames := importNames(file, GeneratedPackagePath)
+	libNames := importNames(file, libErrorsPackagePath)
+
+	var literals []*ast.CompositeLit
+	ast.Inspect(file, func(node ast.Node) bool {
+		switch n := node.(type) {
+		case *ast.SelectorExpr:
+			if isPackageIdent(n.X, generatedNames) {
+				used[strings.TrimPrefix(strings.TrimPrefix(n.Sel.Name, "New"), "Err")] = struct{}{}
+				return true
+			}
+
+			tag, ok := n.X.(*ast.SelectorExpr)
+			if ok && isPackageIdent(tag.X, generatedNames) {
+				used[tag.Sel.Name+n.Sel.Name] = struct{}{}
+				return true
+			}
+
+			ident, ok := n.X.(*ast.Ident)
+			if ok && isPackageIdent(ident, tagNames) {
+				used[ident.Name+n.Sel.Name] = struct{}{}
+			}
+
+		case *ast.CompositeLit:
+			sel, ok := n.Type.(*ast.SelectorExpr)
+			if ok && sel.Sel.Name == "Error" && isPackageIdent(sel.X, libNames) {
+				literals = append(literals, n)
+			}
+		}
+		return true
+	})
+	return literals
+}
+
+// CheckUsages reports the errors of tags not referenced by pkgs and without usedBy,
+// and the liberrors.Error built directly instead of declared in errors.yml,
+// pkgs must be loaded with CheckLoadMode
+func CheckUsages(tags map[string]ErrorMap, pkgs []*packages.Package) []CheckIssue {
+	used := make(map[string]struct{})
+	var issues []CheckIssue
+
+	for _, pkg := range pkgs {
+		if pkg.PkgPath == libErrorsPackagePath {
+			continue
+		}
+
+		tagNames := make(map[string]struct{})
+		if pkg.PkgPath == GeneratedPackagePath {
+			for tagName := range tags {
+				tagNames[strings.Title(tagName)] = struct{}{}
+			}
+		}
+
+		for _, file := range pkg.Syntax {
+			if isGeneratedFile(file) {
+				continue
+			}
+
+			for _, lit := range collectUsages(file, tagNames, used) {
+				issues = append(issues, CheckIssue{
+					Position: pkg.Fset.Position(lit.Pos()).String(),
+					Message:  "liberrors.Error built directly, declare the error in errors.yml",
+				})
+			}
+		}
+	}
+
+	sort.Slice(issues, func(i, j int) bool {
+		return issues[i].Position < issues[j].Position
+	})
+
+	var unused []CheckIssue
+	for _, tag := range tagMapToList(tags) {
+		for _, e := range tag.errors {
+			if e.info.UsedBy != "" {
+				continue
+			}
+			_, existed := used[strings.Title(tag.name)+strings.Title(e.name)]
+			if !existed {
+				unused = append(unused, CheckIssue{
+					Message: fmt.Sprintf("error '%s.%s' is never used", tag.name, e.name),
+				})
+			}
+		}
+	}
+	return append(unused, issues...)
+}
+
+// CheckDrift returns an issue when generated, read from path, is not the output of Generate for tags
+func CheckDrift(tags map[string]ErrorMap, path string, generated []byte) ([]CheckIssue, error) {
+	var buf bytes.Buffer
+	err := Generate(tags, &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.Equal(buf.Bytes(), generated) {
+		return nil, nil
+	}
+	return []CheckIssue{{
+		Position: path,
+		Message:  "out of date with errors.yml, run make gen-error",
+	}}, nil
+}
@@ -0,0 +1,127 @@
+package generate
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"go/parser"
+	"go/token"
+	"golang.org/x/tools/go/packages"
+	"testing"
+)
+
+var checkTestTags = map[string]ErrorMap{
+	"general": {
+		"unknown": {
+			RPCStatus: 2,
+			Code:      "02",
+			Message:   "Unknown",
+		},
+		"internalErrorAccessingDatabase": {
+			RPCStatus: 13,
+			Code:      "1301",
+			Message:   "Error accessing database",
+		},
+	},
+	"todo": {
+		"notFoundTodo": {
+			RPCStatus: 5,
+			Code:      "0501",
+			Message:   "Not found todo",
+		},
+		"notFoundTodoItem": {
+			RPCStatus: 5,
+			Code:      "0502",
+			Message:   "Not found todo item",
+		},
+		"invalidArgumentEmptyItems": {
+			RPCStatus: 3,
+			Code:      "0301",
+			Message:   "Todo items must not be empty",
+		},
+		"invalidArgumentRestorePoint": {
+			RPCStatus: 3,
+			Code:      "0302",
+			Message:   "Exactly one of sequence and time must be set",
+			UsedBy:    "clients",
+		},
+	},
+}
+
+func newCheckTestPackage(t *testing.T, pkgPath string, sources map[string]string) *packages.Package {
+	fset := token.NewFileSet()
+	pkg := &packages.Package{
+		PkgPath: pkgPath,
+		Fset:    fset,
+	}
+	for name, src := range sources {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		assert.Nil(t, err)
+		pkg.Syntax = append(pkg.Syntax, file)
+	}
+	return pkg
+}
+
+func TestCheckUsages(t *testing.T) {
+	service := newCheckTestPackage(t, "todoapp/todoapp/service", map[string]string{
+		"service.go": `package service
+
+import (
+	apperrors "todoapp/pkg/errors"
+	liberrors "todoapp/lib/errors"
+)
+
+func get(found bool) error {
+	if !found {
+		return apperrors.Todo.NotFoundTodo.Err()
+	}
+	return &liberrors.Error{RPCStatus: 9, Code: "0901"}
+}
+`,
+		"item.go": `package service
+
+import "todoapp/pkg/errors"
+
+var errItem = errors.NewErrTodoNotFoundTodoItem()
+`,
+	})
+
+	generated := newCheckTestPackage(t, GeneratedPackagePath, map[string]string{
+		"errors.go": `// Code generated by bin/errors. DO NOT EDIT.
+package errors
+
+var Unknown = General.Unknown
+`,
+		"wrap.go": `package errors
+
+func WrapDBError() error {
+	return General.InternalErrorAccessingDatabase.Err()
+}
+`,
+	})
+
+	issues := CheckUsages(checkTestTags, []*packages.Package{service, generated})
+	assert.Equal(t, []CheckIssue{
+		{Message: "error 'general.unknown' is never used"},
+		{Message: "error 'todo.invalidArgumentEmptyItems' is never used"},
+		{
+			Position: "service.go:12:10",
+			Message:  "liberrors.Error built directly, declare the error in errors.yml",
+		},
+	}, issues)
+}
+
+func TestCheckDrift(t *testing.T) {
+	var buf bytes.Buffer
+	assert.Nil(t, Generate(checkTestTags, &buf))
+
+	issues, err := CheckDrift(checkTestTags, "pkg/errors/errors.go", buf.Bytes())
+	assert.Nil(t, err)
+	assert.Nil(t, issues)
+
+	issues, err = CheckDrift(checkTestTags, "pkg/errors/errors.go", append(buf.Bytes(), '\n'))
+	assert.Nil(t, err)
+	assert.Equal(t, []CheckIssue{{
+		Position: "pkg/errors/errors.go",
+		Message:  "out of date with errors.yml, run make gen-error",
+	}}, issues)
+}
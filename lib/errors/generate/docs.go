@@ -0,0 +1,169 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+)
+
+// DocsFormat output format of GenerateDocs
+type DocsFormat string
+
+const (
+	// DocsFormatMarkdown a Markdown document
+	DocsFormatMarkdown DocsFormat = "markdown"
+	// DocsFormatHTML a standalone HTML page
+	DocsFormatHTML DocsFormat = "html"
+)
+
+type docsDetail struct {
+	Field string
+	Type  string
+}
+
+type docsError struct {
+	Name       string
+	Code       string
+	RPCStatus  uint32
+	RPCCode    string
+	HTTPStatus int
+	Message    string
+	Details    []docsDetail
+	Retry      string
+}
+
+type docsTag struct {
+	Name   string
+	Errors []docsError
+}
+
+func sortedDetails(details map[string]string) []detailEntry {
+	result := make([]detailEntry, 0, len(details))
+	for field, fieldType := range details {
+		result = append(result, detailEntry{
+			field:     field,
+			fieldType: fieldType,
+		})
+	}
+	sort.Sort(sortDetailEntry(result))
+	return result
+}
+
+func docsRetry(info ErrorInfo) string {
+	if !info.Retryable {
+		return "no"
+	}
+	if info.RetryAfter > 0 {
+		return "after " + info.RetryAfter.String()
+	}
+	return "yes"
+}
+
+func tagsToDocs(tags map[string]ErrorMap) []docsTag {
+	tagList := tagMapToList(tags)
+	result := make([]docsTag, 0, len(tagList))
+	for _, tag := range tagList {
+		errors := make([]docsError, 0, len(tag.errors))
+		for _, e := range tag.errors {
+			var details []docsDetail
+			for _, d := range sortedDetails(e.info.Details) {
+				details = append(details, docsDetail{Field: d.field, Type: d.fieldType})
+			}
+
+			rpcCode := codes.Code(e.info.RPCStatus)
+			errors = append(errors, docsError{
+				Name:       e.name,
+				Code:       e.info.Code,
+				RPCStatus:  e.info.RPCStatus,
+				RPCCode:    rpcCode.String(),
+				HTTPStatus: runtime.HTTPStatusFromCode(rpcCode),
+				Message:    e.info.Message,
+				Details:    details,
+				Retry:      docsRetry(e.info),
+			})
+		}
+		result = append(result, docsTag{
+			Name:   tag.name,
+			Errors: errors,
+		})
+	}
+	return result
+}
+
+var markdownEscaper = strings.NewReplacer(`|`, `\|`, "\n", " ")
+
+func generateMarkdown(tags []docsTag, writer io.Writer) error {
+	var buf bytes.Buffer
+
+	buf.WriteString("# Error Codes\n")
+	for _, tag := range tags {
+		buf.WriteString("\n## " + tag.Name + "\n\n")
+		buf.WriteString("| Error | Code | gRPC Status | HTTP Status | Message | Details | Retry |\n")
+		buf.WriteString("| --- | --- | --- | --- | --- | --- | --- |\n")
+
+		for _, e := range tag.Errors {
+			details := make([]string, 0, len(e.Details))
+			for _, d := range e.Details {
+				details = append(details, fmt.Sprintf("`%s`: `%s`", d.Field, d.Type))
+			}
+
+			fmt.Fprintf(&buf, "| `%s` | `%s` | %s (%d) | %d | %s | %s | %s |\n",
+				e.Name, e.Code, e.RPCCode, e.RPCStatus, e.HTTPStatus,
+				markdownEscaper.Replace(e.Message), strings.Join(details, ", "), e.Retry,
+			)
+		}
+	}
+
+	_, err := writer.Write(buf.Bytes())
+	return err
+}
+
+var htmlTemplate = template.Must(template.New("errors").Parse(strings.TrimLeft(`
+<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Error Codes</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+code { font-family: monospace; }
+</style>
+</head>
+<body>
+<h1>Error Codes</h1>
+{{- range .}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+<table>
+<tr><th>Error</th><th>Code</th><th>gRPC Status</th><th>HTTP Status</th><th>Message</th><th>Details</th><th>Retry</th></tr>
+{{- range .Errors}}
+<tr id="{{.Code}}"><td><code>{{.Name}}</code></td><td><code>{{.Code}}</code></td><td>{{.RPCCode}} ({{.RPCStatus}})</td><td>{{.HTTPStatus}}</td><td>{{.Message}}</td><td>
+{{- range $i, $d := .Details}}{{if $i}}<br>{{end}}<code>{{$d.Field}}</code>: <code>{{$d.Type}}</code>{{end -}}
+</td><td>{{.Retry}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+</body>
+</html>
+`, "\n")))
+
+// GenerateDocs writes the catalog of errors in format, ordered by tag and error name
+func GenerateDocs(tags map[string]ErrorMap, format DocsFormat, output io.Writer) error {
+	docs := tagsToDocs(tags)
+
+	switch format {
+	case DocsFormatMarkdown:
+		return generateMarkdown(docs, output)
+	case DocsFormatHTML:
+		return htmlTemplate.Execute(output, docs)
+	default:
+		return fmt.Errorf("unsupported docs format '%s'", format)
+	}
+}
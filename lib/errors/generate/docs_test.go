@@ -0,0 +1,86 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+var docsTestTags = map[string]ErrorMap{
+	"todo": {
+		"notFoundTodo": {
+			RPCStatus: 5,
+			Code:      "0501",
+			Message:   "Not found | todo",
+			Details: map[string]string{
+				"todoId":    "int64",
+				"createdAt": "time.Time",
+			},
+		},
+	},
+	"general": {
+		"unknown": {
+			RPCStatus:  2,
+			Code:       "02",
+			Message:    "Unknown",
+			Retryable:  true,
+			RetryAfter: 5 * time.Second,
+		},
+	},
+}
+
+func TestGenerateDocs_Markdown(t *testing.T) {
+	var buf bytes.Buffer
+	err := GenerateDocs(docsTestTags, DocsFormatMarkdown, &buf)
+	assert.Nil(t, err)
+
+	expected := strings.TrimLeft(`
+# Error Codes
+
+## general
+
+| Error | Code | gRPC Status | HTTP Status | Message | Details | Retry |
+| --- | --- | --- | --- | --- | --- | --- |
+| `+"`unknown` | `02`"+` | Unknown (2) | 500 | Unknown |  | after 5s |
+
+## todo
+
+| Error | Code | gRPC Status | HTTP Status | Message | Details | Retry |
+| --- | --- | --- | --- | --- | --- | --- |
+| `+"`notFoundTodo` | `0501`"+` | NotFound (5) | 404 | Not found \| todo | `+
+		"`createdAt`: `time.Time`, `todoId`: `int64` | no |\n", "\n")
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestGenerateDocs_HTML(t *testing.T) {
+	var buf bytes.Buffer
+	err := GenerateDocs(docsTestTags, DocsFormatHTML, &buf)
+	assert.Nil(t, err)
+
+	html := buf.String()
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>\n"))
+	assert.Contains(t, html, `<tr id="0501"><td><code>notFoundTodo</code></td><td><code>0501</code></td>`+
+		`<td>NotFound (5)</td><td>404</td><td>Not found | todo</td>`+
+		`<td><code>createdAt</code>: <code>time.Time</code><br><code>todoId</code>: <code>int64</code></td><td>no</td></tr>`)
+	assert.True(t, strings.Index(html, `id="general"`) < strings.Index(html, `id="todo"`))
+}
+
+func TestGenerateDocs_Deterministic(t *testing.T) {
+	var first bytes.Buffer
+	assert.Nil(t, GenerateDocs(docsTestTags, DocsFormatHTML, &first))
+
+	for i := 0; i < 10; i++ {
+		var buf bytes.Buffer
+		assert.Nil(t, GenerateDocs(docsTestTags, DocsFormatHTML, &buf))
+		assert.Equal(t, first.String(), buf.String())
+	}
+}
+
+func TestGenerateDocs_UnsupportedFormat(t *testing.T) {
+	var buf bytes.Buffer
+	err := GenerateDocs(docsTestTags, "pdf", &buf)
+	assert.Equal(t, fmt.Errorf("unsupported docs format 'pdf'"), err)
+}
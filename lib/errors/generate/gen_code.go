@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"fmt"
 	"go/format"
+	"google.golang.org/grpc/codes"
 	"io"
 	"sort"
 	"strings"
+	"time"
 )
 
 func generateNewErrorFunc(errName string, info ErrorInfo, writer io.Writer) error {
@@ -15,17 +17,69 @@ func generateNewErrorFunc(errName string, info ErrorInfo, writer io.Writer) erro
 func New%s() *%s {
 	return &%s{
 		RPCStatus: %d,
-		Code:      %q,
-		Message:   %q,
-	}
+		Code: %q,
+		Message: %q,
+%s	}
 }
 `
+	var fields strings.Builder
+	if len(info.Messages) > 0 {
+		fields.WriteString(generateMessages(info.Messages))
+	}
+	if info.Retryable {
+		fields.WriteString("\t\tRetryable: true,\n")
+	}
+	if info.RetryAfter > 0 {
+		fmt.Fprintf(&fields, "\t\tRetryAfter: %s,\n", durationLiteral(info.RetryAfter))
+	}
+
 	code = strings.TrimSpace(code)
-	code = fmt.Sprintf(code, errName, errName, errName, errName, info.RPCStatus, info.Code, info.Message)
-	_, err := writer.Write([]byte(code))
+	code = fmt.Sprintf(code, errName, errName, errName, errName, info.RPCStatus, info.Code, info.Message, fields.String())
+
+	// aligns the fields
+	formatted, err := format.Source([]byte(code))
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(formatted)
 	return err
 }
 
+// durationLiteral returns the Go expression of d in the largest unit dividing it
+func durationLiteral(d time.Duration) string {
+	units := []struct {
+		unit time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+	for _, u := range units {
+		if d%u.unit == 0 {
+			return fmt.Sprintf("%d * %s", d/u.unit, u.name)
+		}
+	}
+	return fmt.Sprintf("time.Duration(%d)", int64(d))
+}
+
+func generateMessages(messages map[string]string) string {
+	locales := make([]string, 0, len(messages))
+	for locale := range messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	var buf strings.Builder
+	buf.WriteString("\t\tMessages: map[string]string{\n")
+	for _, locale := range locales {
+		fmt.Fprintf(&buf, "\t\t\t%q: %q,\n", locale, messages[locale])
+	}
+	buf.WriteString("\t\t},\n")
+	return buf.String()
+}
+
 func generateErrFunc(errName string, writer io.Writer) error {
 	code := `
 // Err ...
@@ -55,6 +109,62 @@ func (e *%s) %s(value %s) *%s {
 	return err
 }
 
+func generateIsFunc(errName string, writer io.Writer) error {
+	code := `
+// Is ...
+func (e *%s) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *%s) From(err error) (*%s, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*%s)(domainErr), true
+}
+`
+	code = strings.TrimSpace(code)
+	code = fmt.Sprintf(code, errName, errName, errName, errName)
+	_, err := writer.Write([]byte(code))
+	return err
+}
+
+func generateGetMethod(errName string, field string, fieldType string, writer io.Writer) error {
+	method := "Get" + strings.Title(field)
+
+	code := `
+// %s ...
+func (e *%s) %s() %s {
+	value, _ := e.Details[%q].(%s)
+	return value
+}
+`
+	code = fmt.Sprintf(code, method, errName, method, fieldType, field, fieldType)
+	code = strings.TrimSpace(code)
+	_, err := writer.Write([]byte(code))
+	return err
+}
+
+func generateWithViolationMethod(errName string, writer io.Writer) error {
+	code := `
+// WithViolation ...
+func (e *%s) WithViolation(field string, description string) *%s {
+	err := (*liberrors.Error)(e)
+	return (*%s)(err.WithViolation(field, description))
+}
+`
+	code = fmt.Sprintf(code, errName, errName, errName)
+	code = strings.TrimSpace(code)
+	_, err := writer.Write([]byte(code))
+	return err
+}
+
 func generateErrorEntry(tagName string, e errorEntry, writer io.Writer) error {
 	name := strings.Title(e.name)
 	name = "Err" + tagName + name
@@ -79,6 +189,14 @@ func generateErrorEntry(tagName string, e errorEntry, writer io.Writer) error {
 		return err
 	}
 
+	if _, err := writer.Write([]byte("\n\n")); err != nil {
+		return err
+	}
+
+	if err := generateIsFunc(name, writer); err != nil {
+		return err
+	}
+
 	details := make([]detailEntry, 0, len(e.info.Details))
 	for field, fieldType := range e.info.Details {
 		details = append(details, detailEntry{
@@ -98,6 +216,26 @@ func generateErrorEntry(tagName string, e errorEntry, writer io.Writer) error {
 		if err != nil {
 			return err
 		}
+
+		_, err = writer.Write([]byte("\n\n"))
+		if err != nil {
+			return err
+		}
+
+		err = generateGetMethod(name, d.field, d.fieldType, writer)
+		if err != nil {
+			return err
+		}
+	}
+
+	if codes.Code(e.info.RPCStatus) == codes.InvalidArgument {
+		if _, err := writer.Write([]byte("\n\n")); err != nil {
+			return err
+		}
+
+		if err := generateWithViolationMethod(name, writer); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -167,8 +305,11 @@ func generateErrorTag(tag errorTagEntry, writer io.Writer) error {
 func checkTimePackageIsRequired(tags map[string]ErrorMap) bool {
 	for _, errMap := range tags {
 		for _, info := range errMap {
+			if info.RetryAfter > 0 {
+				return true
+			}
 			for _, fieldType := range info.Details {
-				if fieldType == "time.Time" {
+				if strings.HasPrefix(fieldType, "time.") {
 					return true
 				}
 			}
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGenerateNewErrorFunc(t *testing.T) {
@@ -31,6 +32,84 @@ func NewErrGeneralNotFound() *ErrGeneralNotFound {
 	assert.Equal(t, expected, buf.String())
 }
 
+func TestGenerateNewErrorFunc_Messages(t *testing.T) {
+	var buf bytes.Buffer
+	err := generateNewErrorFunc("ErrGeneralNotFound", ErrorInfo{
+		RPCStatus: 13,
+		Code:      "1303",
+		Message:   "Not found",
+		Messages: map[string]string{
+			"vi": "Không tìm thấy",
+			"fr": "Introuvable",
+		},
+	}, &buf)
+
+	expected := `
+// NewErrGeneralNotFound ...
+func NewErrGeneralNotFound() *ErrGeneralNotFound {
+	return &ErrGeneralNotFound{
+		RPCStatus: 13,
+		Code:      "1303",
+		Message:   "Not found",
+		Messages: map[string]string{
+			"fr": "Introuvable",
+			"vi": "Không tìm thấy",
+		},
+	}
+}
+`
+	expected = strings.TrimSpace(expected)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestGenerateNewErrorFunc_Retry(t *testing.T) {
+	var buf bytes.Buffer
+	err := generateNewErrorFunc("ErrGeneralUnavailable", ErrorInfo{
+		RPCStatus:  14,
+		Code:       "1400",
+		Message:    "Unavailable",
+		Retryable:  true,
+		RetryAfter: 90 * time.Second,
+	}, &buf)
+
+	expected := `
+// NewErrGeneralUnavailable ...
+func NewErrGeneralUnavailable() *ErrGeneralUnavailable {
+	return &ErrGeneralUnavailable{
+		RPCStatus:  14,
+		Code:       "1400",
+		Message:    "Unavailable",
+		Retryable:  true,
+		RetryAfter: 90 * time.Second,
+	}
+}
+`
+	expected = strings.TrimSpace(expected)
+
+	assert.Nil(t, err)
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestDurationLiteral(t *testing.T) {
+	table := []struct {
+		input    time.Duration
+		expected string
+	}{
+		{input: 2 * time.Hour, expected: "2 * time.Hour"},
+		{input: 90 * time.Minute, expected: "90 * time.Minute"},
+		{input: 1500 * time.Millisecond, expected: "1500 * time.Millisecond"},
+		{input: 1500 * time.Microsecond, expected: "time.Duration(1500000)"},
+	}
+
+	for _, e := range table {
+		t.Run(e.expected, func(t *testing.T) {
+			assert.Equal(t, e.expected, durationLiteral(e.input))
+		})
+	}
+}
+
 func TestGenerateWithMethod(t *testing.T) {
 	var buf bytes.Buffer
 	err := generateWithMethod("ErrGeneralUnknown", "total", "int64", &buf)
@@ -82,6 +161,24 @@ func NewErrGeneralNotFound() *ErrGeneralNotFound {
 func (e *ErrGeneralNotFound) Err() error {
 	return (*liberrors.Error)(e)
 }
+
+// Is ...
+func (e *ErrGeneralNotFound) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrGeneralNotFound) From(err error) (*ErrGeneralNotFound, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrGeneralNotFound)(domainErr), true
+}
 `,
 		},
 		{
@@ -117,17 +214,53 @@ func (e *ErrGeneralUnknown) Err() error {
 	return (*liberrors.Error)(e)
 }
 
+// Is ...
+func (e *ErrGeneralUnknown) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrGeneralUnknown) From(err error) (*ErrGeneralUnknown, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrGeneralUnknown)(domainErr), true
+}
+
 // WithStartedTime ...
 func (e *ErrGeneralUnknown) WithStartedTime(value time.Time) *ErrGeneralUnknown {
 	err := (*liberrors.Error)(e)
 	return (*ErrGeneralUnknown)(err.WithDetail("startedTime", value))
 }
 
+// GetStartedTime ...
+func (e *ErrGeneralUnknown) GetStartedTime() time.Time {
+	value, _ := e.Details["startedTime"].(time.Time)
+	return value
+}
+
 // WithTotal ...
 func (e *ErrGeneralUnknown) WithTotal(value int64) *ErrGeneralUnknown {
 	err := (*liberrors.Error)(e)
 	return (*ErrGeneralUnknown)(err.WithDetail("total", value))
 }
+
+// GetTotal ...
+func (e *ErrGeneralUnknown) GetTotal() int64 {
+	value, _ := e.Details["total"].(int64)
+	return value
+}
+
+// WithViolation ...
+func (e *ErrGeneralUnknown) WithViolation(field string, description string) *ErrGeneralUnknown {
+	err := (*liberrors.Error)(e)
+	return (*ErrGeneralUnknown)(err.WithViolation(field, description))
+}
 `,
 		},
 	}
@@ -183,6 +316,24 @@ func (e *ErrAuthNotFound) Err() error {
 	return (*liberrors.Error)(e)
 }
 
+// Is ...
+func (e *ErrAuthNotFound) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrAuthNotFound) From(err error) (*ErrAuthNotFound, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrAuthNotFound)(domainErr), true
+}
+
 // AuthTag ...
 type AuthTag struct {
 	NotFound *ErrAuthNotFound
@@ -205,3 +356,30 @@ var Auth = &AuthTag{
 		})
 	}
 }
+
+func TestGenerate_DetailTypes(t *testing.T) {
+	var buf bytes.Buffer
+	err := Generate(map[string]ErrorMap{
+		"todo": {
+			"notFoundTodo": {
+				RPCStatus: 5,
+				Code:      "0501",
+				Message:   "Not found todo",
+				Details: map[string]string{
+					"todoId":    "uint32",
+					"itemIds":   "[]int64",
+					"tags":      "[]string",
+					"expiredIn": "time.Duration",
+				},
+			},
+		},
+	}, &buf)
+	assert.Nil(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "\"time\"")
+	assert.Contains(t, output, "func (e *ErrTodoNotFoundTodo) WithTodoId(value uint32) *ErrTodoNotFoundTodo {")
+	assert.Contains(t, output, "func (e *ErrTodoNotFoundTodo) WithItemIds(value []int64) *ErrTodoNotFoundTodo {")
+	assert.Contains(t, output, "func (e *ErrTodoNotFoundTodo) WithTags(value []string) *ErrTodoNotFoundTodo {")
+	assert.Contains(t, output, "func (e *ErrTodoNotFoundTodo) WithExpiredIn(value time.Duration) *ErrTodoNotFoundTodo {")
+}
@@ -1,13 +1,23 @@
 package generate
 
-import "sort"
+import (
+	"sort"
+	"time"
+)
 
 // ErrorInfo error config
 type ErrorInfo struct {
 	RPCStatus uint32            `yaml:"rpcStatus"`
 	Code      string            `yaml:"code"`
 	Message   string            `yaml:"message"`
+	Messages  map[string]string `yaml:"messages"`
 	Details   map[string]string `yaml:"details"`
+	// whether clients can retry the call, after RetryAfter when set, like "5s"
+	Retryable  bool          `yaml:"retryable"`
+	RetryAfter time.Duration `yaml:"retryAfter"`
+	// where the error is used when it is not referenced in this module, like "clients",
+	// CheckUsages does not report the error as never used when set
+	UsedBy string `yaml:"usedBy"`
 }
 
 // ErrorMap map of errors
@@ -0,0 +1,162 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+)
+
+// types of the JSON values written by liberrors.CustomHTTPError
+var typeScriptTypes = map[string]string{
+	"bool":     "boolean",
+	"string":   "string",
+	"int64":    "number",
+	"uint64":   "number",
+	"int32":    "number",
+	"uint32":   "number",
+	"float64":  "number",
+	"[]string": "string[]",
+	"[]int64":  "number[]",
+	// RFC 3339
+	"time.Time": "string",
+	// seconds with the suffix "s", like "1.5s"
+	"time.Duration": "string",
+}
+
+func typeScriptErrorName(tagName string, errorName string) string {
+	return strings.Title(tagName) + strings.Title(errorName)
+}
+
+func generateTypeScriptError(tagName string, e errorEntry, buf *bytes.Buffer) error {
+	name := typeScriptErrorName(tagName, e.name)
+
+	details := sortedDetails(e.info.Details)
+	hasViolations := codes.Code(e.info.RPCStatus) == codes.InvalidArgument
+	detailsType := "never"
+	if len(details) > 0 || hasViolations {
+		detailsType = name + "Details"
+
+		// a type alias and not an interface, to be assignable to Record<string, unknown>
+		fmt.Fprintf(buf, "\nexport type %s = {\n", detailsType)
+		for _, d := range details {
+			tsType, ok := typeScriptTypes[d.fieldType]
+			if !ok {
+				return fmt.Errorf("unsupported detail type '%s' of '%s'", d.fieldType, name)
+			}
+			fmt.Fprintf(buf, "  %s?: %s;\n", d.field, tsType)
+		}
+		if hasViolations {
+			buf.WriteString("  violations?: FieldViolation[];\n")
+		}
+		buf.WriteString("};\n")
+	}
+
+	fmt.Fprintf(buf, "\n// %s\n", e.info.Message)
+	fmt.Fprintf(buf, "export interface %sError {\n", name)
+	fmt.Fprintf(buf, "  code: %q;\n", e.info.Code)
+	buf.WriteString("  message: string;\n")
+	fmt.Fprintf(buf, "  details?: %s;\n", detailsType)
+	buf.WriteString("}\n")
+
+	fmt.Fprintf(buf, "\nexport function is%sError(e: ErrorBody): e is %sError {\n", name, name)
+	fmt.Fprintf(buf, "  return e.code === %q;\n", e.info.Code)
+	buf.WriteString("}\n")
+	return nil
+}
+
+// GenerateTypeScript generates the TypeScript types of the JSON error bodies of the gateway
+func GenerateTypeScript(tags map[string]ErrorMap, output io.Writer) error {
+	var buf bytes.Buffer
+
+	buf.WriteString("// Code generated by bin/errors. DO NOT EDIT.\n")
+
+	tagList := tagMapToList(tags)
+
+	var codes []string
+	var names []string
+	var retryDelays []string
+	for _, tag := range tagList {
+		for _, e := range tag.errors {
+			codes = append(codes, fmt.Sprintf("%q", e.info.Code))
+			names = append(names, typeScriptErrorName(tag.name, e.name)+"Error")
+			if e.info.Retryable {
+				retryDelays = append(retryDelays, fmt.Sprintf("[%q, %s]",
+					e.info.Code, strconv.FormatFloat(e.info.RetryAfter.Seconds(), 'f', -1, 64)))
+			}
+		}
+	}
+
+	buf.WriteString("\nexport type ErrorCode =")
+	for _, c := range codes {
+		buf.WriteString("\n  | " + c)
+	}
+	buf.WriteString(";\n")
+
+	buf.WriteString("\nconst errorCodes: ReadonlySet<string> = new Set<string>([")
+	buf.WriteString(strings.Join(codes, ", "))
+	buf.WriteString("]);\n")
+
+	buf.WriteString(`
+// ErrorBody the JSON body of errors returned by the gateway
+export interface ErrorBody {
+  code: string;
+  message: string;
+  details?: Record<string, unknown>;
+}
+
+// FieldViolation an invalid field of the request, listed in details.violations of invalid argument errors
+export interface FieldViolation {
+  field: string;
+  description: string;
+}
+
+export function isErrorBody(value: unknown): value is ErrorBody {
+  if (typeof value !== "object" || value === null) {
+    return false;
+  }
+  const body = value as Record<string, unknown>;
+  return typeof body.code === "string" && typeof body.message === "string";
+}
+
+export function isAppError(e: ErrorBody): e is AppError {
+  return errorCodes.has(e.code);
+}
+`)
+
+	buf.WriteString("\n// the delays in seconds before retrying the retryable errors, 0 to retry right away\n")
+	buf.WriteString("const retryDelays: ReadonlyMap<string, number> = new Map<string, number>([")
+	buf.WriteString(strings.Join(retryDelays, ", "))
+	buf.WriteString("]);\n")
+
+	buf.WriteString(`
+export function isRetryable(e: ErrorBody): boolean {
+  return retryDelays.has(e.code);
+}
+
+export function retryDelaySeconds(e: ErrorBody): number | undefined {
+  return retryDelays.get(e.code);
+}
+`)
+
+	for _, tag := range tagList {
+		for _, e := range tag.errors {
+			err := generateTypeScriptError(tag.name, e, &buf)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	buf.WriteString("\nexport type AppError =")
+	for _, n := range names {
+		buf.WriteString("\n  | " + n)
+	}
+	buf.WriteString(";\n")
+
+	_, err := output.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,157 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	var buf bytes.Buffer
+	err := GenerateTypeScript(map[string]ErrorMap{
+		"todo": {
+			"notFoundTodo": {
+				RPCStatus: 5,
+				Code:      "0501",
+				Message:   "Not found todo",
+				Details: map[string]string{
+					"todoId":    "int64",
+					"deleted":   "bool",
+					"name":      "string",
+					"ratio":     "float64",
+					"createdAt": "time.Time",
+				},
+			},
+			"invalidArgumentEmptyItems": {
+				RPCStatus: 3,
+				Code:      "0301",
+				Message:   "Todo items must not be empty",
+			},
+		},
+		"general": {
+			"unavailableStorage": {
+				RPCStatus:  14,
+				Code:       "1400",
+				Message:    "Storage unavailable",
+				Retryable:  true,
+				RetryAfter: 1500 * time.Millisecond,
+			},
+		},
+	}, &buf)
+	assert.Nil(t, err)
+
+	expected := strings.TrimLeft(`
+// Code generated by bin/errors. DO NOT EDIT.
+
+export type ErrorCode =
+  | "1400"
+  | "0301"
+  | "0501";
+
+const errorCodes: ReadonlySet<string> = new Set<string>(["1400", "0301", "0501"]);
+
+// ErrorBody the JSON body of errors returned by the gateway
+export interface ErrorBody {
+  code: string;
+  message: string;
+  details?: Record<string, unknown>;
+}
+
+// FieldViolation an invalid field of the request, listed in details.violations of invalid argument errors
+export interface FieldViolation {
+  field: string;
+  description: string;
+}
+
+export function isErrorBody(value: unknown): value is ErrorBody {
+  if (typeof value !== "object" || value === null) {
+    return false;
+  }
+  const body = value as Record<string, unknown>;
+  return typeof body.code === "string" && typeof body.message === "string";
+}
+
+export function isAppError(e: ErrorBody): e is AppError {
+  return errorCodes.has(e.code);
+}
+
+// the delays in seconds before retrying the retryable errors, 0 to retry right away
+const retryDelays: ReadonlyMap<string, number> = new Map<string, number>([["1400", 1.5]]);
+
+export function isRetryable(e: ErrorBody): boolean {
+  return retryDelays.has(e.code);
+}
+
+export function retryDelaySeconds(e: ErrorBody): number | undefined {
+  return retryDelays.get(e.code);
+}
+
+// Storage unavailable
+export interface GeneralUnavailableStorageError {
+  code: "1400";
+  message: string;
+  details?: never;
+}
+
+export function isGeneralUnavailableStorageError(e: ErrorBody): e is GeneralUnavailableStorageError {
+  return e.code === "1400";
+}
+
+export type TodoInvalidArgumentEmptyItemsDetails = {
+  violations?: FieldViolation[];
+};
+
+// Todo items must not be empty
+export interface TodoInvalidArgumentEmptyItemsError {
+  code: "0301";
+  message: string;
+  details?: TodoInvalidArgumentEmptyItemsDetails;
+}
+
+export function isTodoInvalidArgumentEmptyItemsError(e: ErrorBody): e is TodoInvalidArgumentEmptyItemsError {
+  return e.code === "0301";
+}
+
+export type TodoNotFoundTodoDetails = {
+  createdAt?: string;
+  deleted?: boolean;
+  name?: string;
+  ratio?: number;
+  todoId?: number;
+};
+
+// Not found todo
+export interface TodoNotFoundTodoError {
+  code: "0501";
+  message: string;
+  details?: TodoNotFoundTodoDetails;
+}
+
+export function isTodoNotFoundTodoError(e: ErrorBody): e is TodoNotFoundTodoError {
+  return e.code === "0501";
+}
+
+export type AppError =
+  | GeneralUnavailableStorageError
+  | TodoInvalidArgumentEmptyItemsError
+  | TodoNotFoundTodoError;
+`, "\n")
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestGenerateTypeScript_UnsupportedType(t *testing.T) {
+	var buf bytes.Buffer
+	err := GenerateTypeScript(map[string]ErrorMap{
+		"todo": {
+			"notFoundTodo": {
+				RPCStatus: 5,
+				Code:      "0501",
+				Details:   map[string]string{"todoId": "int"},
+			},
+		},
+	}, &buf)
+	assert.Equal(t, fmt.Errorf("unsupported detail type 'int' of 'TodoNotFoundTodo'"), err)
+}
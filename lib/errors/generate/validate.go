@@ -2,10 +2,12 @@ package generate
 
 import (
 	"fmt"
+	"golang.org/x/text/language"
 	"google.golang.org/grpc/codes"
 	"sort"
 	"strconv"
 	"strings"
+	liberrors "todoapp/lib/errors"
 )
 
 func lowerCaseFirstLetter(s string) string {
@@ -13,15 +15,22 @@ func lowerCaseFirstLetter(s string) string {
 }
 
 var unsupportedFields = map[string]struct{}{
-	"code": {},
+	"code":       {},
+	"violations": {},
 }
 
 var supportedTypes = map[string]struct{}{
-	"bool":      {},
-	"string":    {},
-	"int64":     {},
-	"float64":   {},
-	"time.Time": {},
+	"bool":          {},
+	"string":        {},
+	"int64":         {},
+	"uint64":        {},
+	"int32":         {},
+	"uint32":        {},
+	"float64":       {},
+	"[]string":      {},
+	"[]int64":       {},
+	"time.Time":     {},
+	"time.Duration": {},
 }
 
 func validateDetails(details map[string]string) error {
@@ -32,12 +41,46 @@ func validateDetails(details map[string]string) error {
 
 		_, existed := unsupportedFields[field]
 		if existed {
-			return fmt.Errorf("field name 'code' is unsupported")
+			return fmt.Errorf("field name '%s' is unsupported", field)
 		}
 
 		_, ok := supportedTypes[fieldType]
 		if !ok {
-			return fmt.Errorf("only types: bool, string, int64, float64 and time.Time are supported")
+			return fmt.Errorf("only types: bool, string, int64, uint64, int32, uint32, float64, " +
+				"[]string, []int64, time.Time and time.Duration are supported")
+		}
+	}
+	return nil
+}
+
+func validateMessage(message string, details map[string]string) error {
+	fields, err := liberrors.MessagePlaceholders(message)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		_, existed := details[field]
+		if !existed {
+			return fmt.Errorf("message references undeclared detail field '%s'", field)
+		}
+	}
+	return nil
+}
+
+func validateMessages(info ErrorInfo) error {
+	for locale, message := range info.Messages {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			return fmt.Errorf("invalid locale '%s': %v", locale, err)
+		}
+		if tag == language.Make(liberrors.DefaultLocale) {
+			return fmt.Errorf("locale '%s' is the default message", locale)
+		}
+
+		err = validateMessage(message, info.Details)
+		if err != nil {
+			return fmt.Errorf("message of locale '%s': %v", locale, err)
 		}
 	}
 	return nil
@@ -64,6 +107,23 @@ func validateError(name string, info ErrorInfo) error {
 		return err
 	}
 
+	err = validateMessage(info.Message, info.Details)
+	if err != nil {
+		return err
+	}
+
+	err = validateMessages(info)
+	if err != nil {
+		return err
+	}
+
+	if info.RetryAfter < 0 {
+		return fmt.Errorf("retryAfter must not be negative")
+	}
+	if info.RetryAfter > 0 && !info.Retryable {
+		return fmt.Errorf("retryAfter is only allowed on retryable errors")
+	}
+
 	return nil
 }
 
@@ -88,6 +148,25 @@ func Validate(tags map[string]ErrorMap) error {
 	return nil
 }
 
+// ValidateLocales validates every error has a message for each of the required locales
+func ValidateLocales(tags map[string]ErrorMap, required []string) error {
+	for _, tag := range tagMapToList(tags) {
+		for _, e := range tag.errors {
+			for _, locale := range required {
+				if locale == liberrors.DefaultLocale {
+					continue
+				}
+
+				_, existed := e.info.Messages[locale]
+				if !existed {
+					return fmt.Errorf("error '%s' missing message of locale '%s'", e.name, locale)
+				}
+			}
+		}
+	}
+	return nil
+}
+
 func findNextErrorCode(rpcStatus uint32, inputCodes []int) string {
 	codeNums := make([]int, 0)
 	codeSet := make(map[int]struct{})
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestValidateError(t *testing.T) {
@@ -70,6 +71,18 @@ func TestValidateError(t *testing.T) {
 			},
 			err: fmt.Errorf("field name 'code' is unsupported"),
 		},
+		{
+			name:      "violations field",
+			errorName: "unauthenticated",
+			info: ErrorInfo{
+				RPCStatus: 16,
+				Code:      "1600",
+				Details: map[string]string{
+					"violations": "string",
+				},
+			},
+			err: fmt.Errorf("field name 'violations' is unsupported"),
+		},
 		{
 			name:      "unsupported detail type",
 			errorName: "unauthenticated",
@@ -80,7 +93,108 @@ func TestValidateError(t *testing.T) {
 					"max": "int",
 				},
 			},
-			err: fmt.Errorf("only types: bool, string, int64, float64 and time.Time are supported"),
+			err: fmt.Errorf("only types: bool, string, int64, uint64, int32, uint32, float64, " +
+				"[]string, []int64, time.Time and time.Duration are supported"),
+		},
+		{
+			name:      "message undeclared field",
+			errorName: "unauthenticated",
+			info: ErrorInfo{
+				RPCStatus: 16,
+				Code:      "1600",
+				Message:   "User {userId} is not authenticated",
+				Details: map[string]string{
+					"userName": "string",
+				},
+			},
+			err: fmt.Errorf("message references undeclared detail field 'userId'"),
+		},
+		{
+			name:      "message invalid braces",
+			errorName: "unauthenticated",
+			info: ErrorInfo{
+				RPCStatus: 16,
+				Code:      "1600",
+				Message:   "User {user id} is not authenticated",
+			},
+			err: fmt.Errorf("invalid braces in message template 'User {user id} is not authenticated'"),
+		},
+		{
+			name:      "message template",
+			errorName: "unauthenticated",
+			info: ErrorInfo{
+				RPCStatus: 16,
+				Code:      "1600",
+				Message:   "User {userId} is not authenticated",
+				Details: map[string]string{
+					"userId": "int64",
+				},
+			},
+			err: nil,
+		},
+		{
+			name:      "default locale message",
+			errorName: "unauthenticated",
+			info: ErrorInfo{
+				RPCStatus: 16,
+				Code:      "1600",
+				Message:   "Not authenticated",
+				Messages: map[string]string{
+					"en": "Not authenticated",
+				},
+			},
+			err: fmt.Errorf("locale 'en' is the default message"),
+		},
+		{
+			name:      "locale message undeclared field",
+			errorName: "unauthenticated",
+			info: ErrorInfo{
+				RPCStatus: 16,
+				Code:      "1600",
+				Message:   "Not authenticated",
+				Messages: map[string]string{
+					"vi": "Người dùng {userId} chưa xác thực",
+				},
+			},
+			err: fmt.Errorf("message of locale 'vi': message references undeclared detail field 'userId'"),
+		},
+		{
+			name:      "locale message template",
+			errorName: "unauthenticated",
+			info: ErrorInfo{
+				RPCStatus: 16,
+				Code:      "1600",
+				Message:   "User {userId} is not authenticated",
+				Messages: map[string]string{
+					"vi":    "Người dùng {userId} chưa xác thực",
+					"pt-BR": "Usuário {userId} não autenticado",
+				},
+				Details: map[string]string{
+					"userId": "int64",
+				},
+			},
+			err: nil,
+		},
+		{
+			name:      "negative retry after",
+			errorName: "unavailable",
+			info: ErrorInfo{
+				RPCStatus:  14,
+				Code:       "1400",
+				Retryable:  true,
+				RetryAfter: -time.Second,
+			},
+			err: fmt.Errorf("retryAfter must not be negative"),
+		},
+		{
+			name:      "retry after not retryable",
+			errorName: "unavailable",
+			info: ErrorInfo{
+				RPCStatus:  14,
+				Code:       "1400",
+				RetryAfter: time.Second,
+			},
+			err: fmt.Errorf("retryAfter is only allowed on retryable errors"),
 		},
 		{
 			name:      "ok",
@@ -170,6 +284,52 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidateLocales(t *testing.T) {
+	tags := map[string]ErrorMap{
+		"general": {
+			"unknown": {
+				RPCStatus: 2,
+				Code:      "02",
+				Message:   "Unknown",
+				Messages: map[string]string{
+					"vi": "Lỗi không xác định",
+				},
+			},
+			"unknownField": {
+				RPCStatus: 2,
+				Code:      "0201",
+				Message:   "Unknown field",
+			},
+		},
+	}
+
+	table := []struct {
+		name     string
+		required []string
+		err      error
+	}{
+		{
+			name: "none",
+		},
+		{
+			name:     "default locale",
+			required: []string{"en"},
+		},
+		{
+			name:     "missing",
+			required: []string{"en", "vi"},
+			err:      fmt.Errorf("error 'unknownField' missing message of locale 'vi'"),
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			err := ValidateLocales(tags, e.required)
+			assert.Equal(t, e.err, err)
+		})
+	}
+}
+
 func TestFindNextErrorCode(t *testing.T) {
 	table := []struct {
 		name      string
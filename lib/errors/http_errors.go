@@ -7,6 +7,8 @@ import (
 	"io"
 	"net/http"
 	"net/textproto"
+	"strconv"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc/grpclog"
@@ -23,7 +25,7 @@ type errorBodyWithDetails struct {
 	Details map[string]interface{} `json:"details"`
 }
 
-func statusToErrorBody(s *status.Status) interface{} {
+func statusToErrorBody(s *status.Status, acceptLanguage string) interface{} {
 	domainErr, ok := FromRPCStatus(s)
 	if !ok {
 		return errorBody{
@@ -32,24 +34,70 @@ func statusToErrorBody(s *status.Status) interface{} {
 		}
 	}
 
-	if domainErr.Details == nil || len(domainErr.Details) == 0 {
+	// the messages are already formatted by ToLocalizedRPCError, formatting them again
+	// would substitute the placeholders contained in the detail values
+	locale := domainErr.SelectLocale(acceptLanguage)
+	message := domainErr.LocalizedMessage(locale)
+
+	if len(domainErr.Details) == 0 && len(domainErr.Violations) == 0 {
 		return errorBody{
 			Code:    domainErr.Code,
-			Message: domainErr.Message,
+			Message: message,
 		}
 	}
 
 	return errorBodyWithDetails{
 		Code:    domainErr.Code,
-		Message: domainErr.Message,
-		Details: domainErr.Details,
+		Message: message,
+		Details: detailsToJSON(domainErr.Details, domainErr.Violations),
+	}
+}
+
+// detailsToJSON converts the detail values not having the JSON form of the proto3 mapping,
+// durations to seconds with the suffix "s", and empty lists to [] instead of null,
+// the field violations are listed in details.violations
+func detailsToJSON(details map[string]interface{}, violations []FieldViolation) map[string]interface{} {
+	result := make(map[string]interface{}, len(details)+1)
+	if len(violations) > 0 {
+		result[violationsDetailField] = violations
 	}
+
+	for field, value := range details {
+		switch v := value.(type) {
+		case time.Duration:
+			result[field] = strconv.FormatFloat(v.Seconds(), 'f', -1, 64) + "s"
+		case []string:
+			if v == nil {
+				v = []string{}
+			}
+			result[field] = v
+		case []int64:
+			if v == nil {
+				v = []int64{}
+			}
+			result[field] = v
+		default:
+			result[field] = value
+		}
+	}
+	return result
+}
+
+// setRetryAfterHeader sets the Retry-After header in seconds, rounded up, of retryable errors with a delay
+func setRetryAfterHeader(w http.ResponseWriter, s *status.Status) {
+	domainErr, ok := FromRPCStatus(s)
+	if !ok || !domainErr.Retryable || domainErr.RetryAfter <= 0 {
+		return
+	}
+
+	seconds := (domainErr.RetryAfter + time.Second - 1) / time.Second
+	w.Header().Set("Retry-After", strconv.FormatInt(int64(seconds), 10))
 }
 
 // CustomHTTPError for customizing error returning of gRPC gateway
 func CustomHTTPError(
 	ctx context.Context, mux *runtime.ServeMux, marshaller runtime.Marshaler,
-	w http.ResponseWriter, _ *http.Request, err error,
+	w http.ResponseWriter, r *http.Request, err error,
 ) {
 	const fallback = `{"error": "failed to marshal error message"}`
 
@@ -61,7 +109,8 @@ func CustomHTTPError(
 	contentType := marshaller.ContentType(pb)
 	w.Header().Set("Content-Type", contentType)
 
-	body := statusToErrorBody(s)
+	body := statusToErrorBody(s, r.Header.Get("Accept-Language"))
+	setRetryAfterHeader(w, s)
 
 	buf, merr := marshaller.Marshal(body)
 	if merr != nil {
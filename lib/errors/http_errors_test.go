@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCustomHTTPError_DetailTypes(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0502",
+		Message:   "Not found todo items",
+		Details: map[string]interface{}{
+			"todoId":    uint32(12),
+			"itemIds":   []int64{3, 5},
+			"tags":      []string{},
+			"expiredIn": 1500 * time.Millisecond,
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/todos/12", nil)
+	w := httptest.NewRecorder()
+
+	CustomHTTPError(context.Background(), runtime.NewServeMux(), &runtime.JSONPb{}, w, r, e.ToRPCError())
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{
+		"code": "0502",
+		"message": "Not found todo items",
+		"details": {"todoId": 12, "itemIds": [3, 5], "tags": [], "expiredIn": "1.5s"}
+	}`, w.Body.String())
+}
+
+func TestCustomHTTPError_Violations(t *testing.T) {
+	e := (&Error{
+		RPCStatus: 3,
+		Code:      "0301",
+		Message:   "Invalid todo",
+	}).
+		WithViolation("name", "must not be empty").
+		WithViolation("items[1].name", "must not exceed 100 characters")
+
+	r := httptest.NewRequest(http.MethodPost, "/api/todos", nil)
+	w := httptest.NewRecorder()
+
+	CustomHTTPError(context.Background(), runtime.NewServeMux(), &runtime.JSONPb{}, w, r, e.ToRPCError())
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.JSONEq(t, `{
+		"code": "0301",
+		"message": "Invalid todo",
+		"details": {
+			"violations": [
+				{"field": "name", "description": "must not be empty"},
+				{"field": "items[1].name", "description": "must not exceed 100 characters"}
+			]
+		}
+	}`, w.Body.String())
+}
+
+func TestCustomHTTPError_DetailWithPlaceholder(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Not found todo {name}",
+		Details: map[string]interface{}{
+			"name": "{id}",
+			"id":   int64(3),
+		},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/api/todos", nil)
+	w := httptest.NewRecorder()
+
+	CustomHTTPError(context.Background(), runtime.NewServeMux(), &runtime.JSONPb{}, w, r, e.ToRPCError())
+
+	assert.JSONEq(t, `{
+		"code": "0501",
+		"message": "Not found todo {id}",
+		"details": {"name": "{id}", "id": 3}
+	}`, w.Body.String())
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/metadata"
+	"sort"
+)
+
+// DefaultLocale the locale of Error.Message
+const DefaultLocale = "en"
+
+// AcceptLanguageMetadata the gRPC metadata key selecting the locale of error messages,
+// with the same syntax as the Accept-Language HTTP header
+const AcceptLanguageMetadata = "accept-language"
+
+// SelectLocale returns the locale in DefaultLocale and the keys of Messages best matching acceptLanguage
+func (e *Error) SelectLocale(acceptLanguage string) string {
+	if len(e.Messages) == 0 || acceptLanguage == "" {
+		return DefaultLocale
+	}
+
+	desired, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(desired) == 0 {
+		return DefaultLocale
+	}
+
+	locales := make([]string, 0, len(e.Messages))
+	for locale := range e.Messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	supported := []language.Tag{language.Make(DefaultLocale)}
+	for _, locale := range locales {
+		supported = append(supported, language.Make(locale))
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(desired...)
+	if confidence == language.No || index == 0 {
+		return DefaultLocale
+	}
+	return locales[index-1]
+}
+
+// LocalizedMessage returns the message template for locale, the default message when missing
+func (e *Error) LocalizedMessage(locale string) string {
+	message, ok := e.Messages[locale]
+	if !ok {
+		return e.Message
+	}
+	return message
+}
+
+// AcceptLanguageFromContext returns the locale preference of a gRPC call,
+// from the accept-language metadata or the Accept-Language header forwarded by the gateway
+func AcceptLanguageFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	for _, key := range []string{AcceptLanguageMetadata, runtime.MetadataPrefix + AcceptLanguageMetadata} {
+		values := md.Get(key)
+		if len(values) > 0 {
+			return values[0]
+		}
+	}
+	return ""
+}
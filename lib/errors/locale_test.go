@@ -0,0 +1,152 @@
+package errors
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newLocalizedError() *Error {
+	return &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Not found todo {todoId}",
+		Messages: map[string]string{
+			"vi": "Không tìm thấy todo {todoId}",
+		},
+	}
+}
+
+func TestError_SelectLocale(t *testing.T) {
+	table := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{
+			name:     "empty",
+			expected: "en",
+		},
+		{
+			name:           "exact",
+			acceptLanguage: "vi",
+			expected:       "vi",
+		},
+		{
+			name:           "region",
+			acceptLanguage: "vi-VN",
+			expected:       "vi",
+		},
+		{
+			name:           "quality",
+			acceptLanguage: "en-US;q=0.5, vi;q=0.8",
+			expected:       "vi",
+		},
+		{
+			name:           "default preferred",
+			acceptLanguage: "en-GB, vi;q=0.8",
+			expected:       "en",
+		},
+		{
+			name:           "unsupported",
+			acceptLanguage: "ja",
+			expected:       "en",
+		},
+		{
+			name:           "invalid",
+			acceptLanguage: "vi;q=abc",
+			expected:       "en",
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			assert.Equal(t, e.expected, newLocalizedError().SelectLocale(e.acceptLanguage))
+		})
+	}
+}
+
+func TestError_ToLocalizedRPCError(t *testing.T) {
+	e := newLocalizedError().WithDetail("todoId", int64(12))
+
+	st := status.Convert(e.ToLocalizedRPCError("vi-VN"))
+	assert.Equal(t, "Not found todo 12", st.Message())
+
+	var localized *errdetails.LocalizedMessage
+	for _, d := range st.Details() {
+		if m, ok := d.(*errdetails.LocalizedMessage); ok {
+			localized = m
+		}
+	}
+	assert.Equal(t, "vi", localized.Locale)
+	assert.Equal(t, "Không tìm thấy todo 12", localized.Message)
+
+	e1, ok := FromRPCStatus(st)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"vi": "Không tìm thấy todo 12"}, e1.Messages)
+	assert.Equal(t, map[string]interface{}{"todoId": int64(12)}, e1.Details)
+}
+
+func TestError_ToLocalizedRPCError_Default(t *testing.T) {
+	e := newLocalizedError().WithDetail("todoId", int64(12))
+
+	e1, ok := FromRPCError(e.ToLocalizedRPCError("en"))
+	assert.True(t, ok)
+	assert.Nil(t, e1.Messages)
+	assert.Equal(t, "Not found todo 12", e1.Message)
+}
+
+func TestAcceptLanguageFromContext(t *testing.T) {
+	table := []struct {
+		name     string
+		md       metadata.MD
+		expected string
+	}{
+		{
+			name: "none",
+			md:   metadata.Pairs(),
+		},
+		{
+			name:     "grpc",
+			md:       metadata.Pairs("accept-language", "vi"),
+			expected: "vi",
+		},
+		{
+			name:     "gateway",
+			md:       metadata.Pairs("grpcgateway-accept-language", "vi-VN"),
+			expected: "vi-VN",
+		},
+		{
+			name:     "grpc preferred",
+			md:       metadata.Pairs("accept-language", "en", "grpcgateway-accept-language", "vi"),
+			expected: "en",
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), e.md)
+			assert.Equal(t, e.expected, AcceptLanguageFromContext(ctx))
+		})
+	}
+}
+
+func TestCustomHTTPError_AcceptLanguage(t *testing.T) {
+	e := newLocalizedError().WithDetail("todoId", int64(12))
+	err := e.ToLocalizedRPCError("vi")
+
+	r := httptest.NewRequest(http.MethodGet, "/api/todos/12", nil)
+	r.Header.Set("Accept-Language", "vi")
+	w := httptest.NewRecorder()
+
+	CustomHTTPError(context.Background(), runtime.NewServeMux(), &runtime.JSONPb{}, w, r, err)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"code":"0501","message":"Không tìm thấy todo 12","details":{"todoId":12}}`, w.Body.String())
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{([A-Za-z][A-Za-z0-9_]*)\}`)
+
+// MessagePlaceholders returns the detail fields referenced by a message template like "Todo {todoId} not found",
+// returns an error on braces not part of a placeholder
+func MessagePlaceholders(message string) ([]string, error) {
+	var fields []string
+	for _, m := range placeholderRegexp.FindAllStringSubmatch(message, -1) {
+		fields = append(fields, m[1])
+	}
+
+	rest := placeholderRegexp.ReplaceAllString(message, "")
+	if strings.ContainsAny(rest, "{}") {
+		return nil, fmt.Errorf("invalid braces in message template '%s'", message)
+	}
+	return fields, nil
+}
+
+func formatDetailValue(value interface{}) string {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case []string:
+		return strings.Join(v, ", ")
+	case []int64:
+		values := make([]string, 0, len(v))
+		for _, n := range v {
+			values = append(values, strconv.FormatInt(n, 10))
+		}
+		return strings.Join(values, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// FormatMessage returns the message with the placeholders replaced by the values of details,
+// placeholders of details not set are replaced by empty strings
+func (e *Error) FormatMessage() string {
+	return e.formatTemplate(e.Message)
+}
+
+func (e *Error) formatTemplate(template string) string {
+	return placeholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := e.Details[placeholder[1:len(placeholder)-1]]
+		if !ok {
+			return ""
+		}
+		return formatDetailValue(value)
+	})
+}
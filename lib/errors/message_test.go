@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestMessagePlaceholders(t *testing.T) {
+	table := []struct {
+		name     string
+		message  string
+		expected []string
+		err      error
+	}{
+		{
+			name:    "no placeholders",
+			message: "Not found todo",
+		},
+		{
+			name:     "placeholders",
+			message:  "Todo {todoId} of {userName} not found",
+			expected: []string{"todoId", "userName"},
+		},
+		{
+			name:    "unclosed",
+			message: "Todo {todoId not found",
+			err:     fmt.Errorf("invalid braces in message template 'Todo {todoId not found'"),
+		},
+		{
+			name:    "empty",
+			message: "Todo {} not found",
+			err:     fmt.Errorf("invalid braces in message template 'Todo {} not found'"),
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			fields, err := MessagePlaceholders(e.message)
+			assert.Equal(t, e.err, err)
+			assert.Equal(t, e.expected, fields)
+		})
+	}
+}
+
+func TestError_FormatMessage(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Todo {todoId} not found since {deletedAt}, owner {owner}",
+		Details: map[string]interface{}{
+			"todoId":    int64(12),
+			"deletedAt": time.Date(2021, 1, 10, 8, 30, 0, 0, time.UTC),
+		},
+	}
+	assert.Equal(t, "Todo 12 not found since 2021-01-10T08:30:00Z, owner ", e.FormatMessage())
+
+	rpcErr := e.ToRPCError()
+	assert.Equal(t, "rpc error: code = NotFound desc = Todo 12 not found since 2021-01-10T08:30:00Z, owner ",
+		rpcErr.Error())
+}
+
+func TestError_FormatMessage_WithoutDetails(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0501",
+		Message:   "Not found todo {todoId}",
+	}
+	assert.Equal(t, "Not found todo ", e.FormatMessage())
+}
+
+func TestError_FormatMessage_Lists(t *testing.T) {
+	e := &Error{
+		RPCStatus: 5,
+		Code:      "0502",
+		Message:   "Not found items {itemIds} of {tags}, retry in {retryIn}",
+		Details: map[string]interface{}{
+			"itemIds": []int64{3, 5},
+			"tags":    []string{"work", "urgent"},
+			"retryIn": 90 * time.Second,
+		},
+	}
+	assert.Equal(t, "Not found items 3, 5 of work, urgent, retry in 1m30s", e.FormatMessage())
+}
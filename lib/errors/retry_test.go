@@ -0,0 +1,128 @@
+package errors
+
+import (
+	"context"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestError_ToRPCError_RetryInfo(t *testing.T) {
+	table := []struct {
+		name string
+		err  *Error
+	}{
+		{
+			name: "not retryable",
+			err: &Error{
+				RPCStatus: 14,
+				Code:      "1400",
+				Message:   "Unavailable",
+			},
+		},
+		{
+			name: "retryable",
+			err: &Error{
+				RPCStatus: 14,
+				Code:      "1400",
+				Message:   "Unavailable",
+				Retryable: true,
+			},
+		},
+		{
+			name: "retry after",
+			err: &Error{
+				RPCStatus:  14,
+				Code:       "1400",
+				Message:    "Unavailable",
+				Retryable:  true,
+				RetryAfter: 1500 * time.Millisecond,
+			},
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			st := status.Convert(e.err.ToRPCError())
+
+			var retryInfo *errdetails.RetryInfo
+			for _, d := range st.Details() {
+				if r, ok := d.(*errdetails.RetryInfo); ok {
+					retryInfo = r
+				}
+			}
+			assert.Equal(t, e.err.Retryable, retryInfo != nil)
+
+			e1, ok := FromRPCStatus(st)
+			assert.True(t, ok)
+			assert.Equal(t, e.err.Retryable, e1.Retryable)
+			assert.Equal(t, e.err.RetryAfter, e1.RetryAfter)
+		})
+	}
+}
+
+func TestCustomHTTPError_RetryAfter(t *testing.T) {
+	table := []struct {
+		name     string
+		err      *Error
+		expected string
+	}{
+		{
+			name: "not retryable",
+			err: &Error{
+				RPCStatus: 14,
+				Code:      "1400",
+				Message:   "Unavailable",
+			},
+		},
+		{
+			name: "retryable without delay",
+			err: &Error{
+				RPCStatus: 14,
+				Code:      "1400",
+				Message:   "Unavailable",
+				Retryable: true,
+			},
+		},
+		{
+			name: "seconds",
+			err: &Error{
+				RPCStatus:  14,
+				Code:       "1400",
+				Message:    "Unavailable",
+				Retryable:  true,
+				RetryAfter: 2 * time.Second,
+			},
+			expected: "2",
+		},
+		{
+			name: "rounded up",
+			err: &Error{
+				RPCStatus:  14,
+				Code:       "1400",
+				Message:    "Unavailable",
+				Retryable:  true,
+				RetryAfter: 1500 * time.Millisecond,
+			},
+			expected: "2",
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/api/todos/12", nil)
+			w := httptest.NewRecorder()
+
+			CustomHTTPError(context.Background(), runtime.NewServeMux(), &runtime.JSONPb{}, w, r, e.err.ToRPCError())
+
+			assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+			assert.Equal(t, e.expected, w.Header().Get("Retry-After"))
+			assert.JSONEq(t, `{"code":"1400","message":"Unavailable"}`, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// violationsDetailField the field of the gateway error body details listing the field violations
+const violationsDetailField = "violations"
+
+// FieldViolation an invalid field of a request, like "items[1].name"
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// WithViolation returns a new error with the field violation appended
+func (e *Error) WithViolation(field string, description string) *Error {
+	violations := make([]FieldViolation, 0, len(e.Violations)+1)
+	violations = append(violations, e.Violations...)
+	violations = append(violations, FieldViolation{
+		Field:       field,
+		Description: description,
+	})
+
+	result := *e
+	result.Violations = violations
+	return &result
+}
+
+func violationsToBadRequest(violations []FieldViolation) *errdetails.BadRequest {
+	result := make([]*errdetails.BadRequest_FieldViolation, 0, len(violations))
+	for _, v := range violations {
+		result = append(result, &errdetails.BadRequest_FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	return &errdetails.BadRequest{FieldViolations: result}
+}
+
+func badRequestToViolations(badRequest *errdetails.BadRequest) []FieldViolation {
+	result := make([]FieldViolation, 0, len(badRequest.FieldViolations))
+	for _, v := range badRequest.FieldViolations {
+		result = append(result, FieldViolation{
+			Field:       v.Field,
+			Description: v.Description,
+		})
+	}
+	return result
+}
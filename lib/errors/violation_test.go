@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func newViolationError() *Error {
+	return &Error{
+		RPCStatus: 3,
+		Code:      "0301",
+		Message:   "Invalid todo",
+	}
+}
+
+func TestError_WithViolation(t *testing.T) {
+	e0 := newViolationError().WithViolation("name", "must not be empty")
+	e1 := e0.WithViolation("items[1].name", "must not exceed 100 characters")
+
+	assert.Equal(t, []FieldViolation{
+		{Field: "name", Description: "must not be empty"},
+	}, e0.Violations)
+	assert.Equal(t, []FieldViolation{
+		{Field: "name", Description: "must not be empty"},
+		{Field: "items[1].name", Description: "must not exceed 100 characters"},
+	}, e1.Violations)
+
+	expected := "rpc status: InvalidArgument, code: 0301, message: Invalid todo, details: map[], " +
+		"violations: [{name must not be empty} {items[1].name must not exceed 100 characters}]"
+	assert.Equal(t, expected, e1.Error())
+}
+
+func TestError_ToRPCError_Violations(t *testing.T) {
+	e := newViolationError().
+		WithDetail("todoId", int64(12)).
+		WithViolation("name", "must not be empty").
+		WithViolation("items[2].name", "must not exceed 100 characters")
+
+	st := status.Convert(e.ToRPCError())
+
+	var badRequest *errdetails.BadRequest
+	for _, d := range st.Details() {
+		if b, ok := d.(*errdetails.BadRequest); ok {
+			badRequest = b
+		}
+	}
+	assert.Equal(t, 2, len(badRequest.FieldViolations))
+	assert.Equal(t, "name", badRequest.FieldViolations[0].Field)
+	assert.Equal(t, "must not be empty", badRequest.FieldViolations[0].Description)
+
+	e1, ok := FromRPCStatus(st)
+	assert.True(t, ok)
+	assert.Equal(t, e, e1)
+}
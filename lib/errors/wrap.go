@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+const maxStackDepth = 32
+
+// Wrap returns a new error caused by cause, capturing the stack of the caller
+func (e *Error) Wrap(cause error) *Error {
+	return e.WrapCaller(cause, 1)
+}
+
+// WrapCaller is Wrap for helpers wrapping errors for their callers,
+// the stack starts skip frames above the caller of WrapCaller
+func (e *Error) WrapCaller(cause error, skip int) *Error {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(skip+2, pcs)
+
+	result := *e
+	result.Cause = cause
+	result.stack = pcs[:n]
+	return &result
+}
+
+// Unwrap returns the cause, for errors.Is and errors.As
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// StackTrace returns the stack captured by Wrap, one function and its file:line per frame,
+// empty when not wrapped
+func (e *Error) StackTrace() string {
+	if len(e.stack) == 0 {
+		return ""
+	}
+
+	var buf strings.Builder
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+		fmt.Fprintf(&buf, "%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		if !more {
+			break
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+	"testing"
+)
+
+func TestError_Wrap(t *testing.T) {
+	e0 := &Error{
+		RPCStatus: 13,
+		Code:      "1301",
+		Message:   "Error accessing database",
+	}
+	e1 := e0.Wrap(sql.ErrConnDone)
+
+	assert.Nil(t, e0.Cause)
+	assert.Equal(t, "", e0.StackTrace())
+
+	assert.Equal(t, sql.ErrConnDone, e1.Unwrap())
+	assert.True(t, stderrors.Is(e1, sql.ErrConnDone))
+	assert.True(t, stderrors.Is(e1, e0))
+	assert.Contains(t, e1.StackTrace(), "todoapp/lib/errors.TestError_Wrap")
+	assert.Equal(t, "rpc status: Internal, code: 1301, message: Error accessing database, details: map[], "+
+		"cause: sql: connection is already closed", e1.Error())
+
+	e2 := e1.WithDetail("table", "todos")
+	assert.Equal(t, sql.ErrConnDone, e2.Cause)
+	assert.Equal(t, e1.StackTrace(), e2.StackTrace())
+}
+
+func TestError_Wrap_NotSent(t *testing.T) {
+	e := (&Error{
+		RPCStatus: 13,
+		Code:      "1301",
+		Message:   "Error accessing database",
+	}).Wrap(sql.ErrConnDone)
+
+	st := status.Convert(e.ToRPCError())
+	assert.Equal(t, "Error accessing database", st.Message())
+
+	e1, ok := FromRPCStatus(st)
+	assert.True(t, ok)
+	assert.Nil(t, e1.Cause)
+	assert.Equal(t, map[string]interface{}{}, e1.Details)
+}
+
+func TestUnaryServerInterceptor_Logging(t *testing.T) {
+	domainErr := &Error{
+		RPCStatus: 13,
+		Code:      "1301",
+		Message:   "Error accessing database",
+	}
+
+	table := []struct {
+		name     string
+		err      error
+		logged   int
+		message  string
+		internal bool
+	}{
+		{
+			name:   "domain error",
+			err:    domainErr,
+			logged: 0,
+		},
+		{
+			name:    "wrapped domain error",
+			err:     domainErr.Wrap(sql.ErrConnDone),
+			logged:  1,
+			message: "Domain error",
+		},
+		{
+			name:     "unknown error",
+			err:      sql.ErrConnDone,
+			logged:   1,
+			message:  "Unknown error",
+			internal: true,
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			core, logs := observer.New(zap.InfoLevel)
+			ctx := ctxzap.ToContext(context.Background(), zap.New(core))
+
+			_, err := UnaryServerInterceptor(ctx, nil, &grpc.UnaryServerInfo{},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					return nil, e.err
+				},
+			)
+			assert.NotNil(t, err)
+			if !e.internal {
+				assert.NotContains(t, status.Convert(err).Message(), "connection is already closed")
+			}
+
+			assert.Equal(t, e.logged, logs.Len())
+			if e.logged > 0 {
+				entry := logs.All()[0]
+				assert.Equal(t, e.message, entry.Message)
+				assert.Contains(t, entry.ContextMap()["error"], "connection is already closed")
+			}
+		})
+	}
+}
@@ -0,0 +1,554 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrDrainTimeout is logged when publishers are still publishing after the drain timeout,
+// their events are reported by Pending
+var ErrDrainTimeout = errors.New("drain timeout exceeded, publishers still publishing")
+
+// PublisherID ...
+type PublisherID uint32
+
+// Repository ...
+type Repository interface {
+	GetLastEvents(limit uint64) ([]Event, error)
+	GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]Event, error)
+	GetUnprocessedEvents(limit uint64) ([]Event, error)
+
+	GetLastSequence(id PublisherID) (uint64, error)
+	SaveLastSequence(id PublisherID, seq uint64) error
+
+	UpdateSequences(events []Event) error
+}
+
+// Publisher ...
+type Publisher interface {
+	GetID() PublisherID
+	Publish(events []Event) error
+}
+
+// Notifier wakes up the DB processor when new events are inserted,
+// Run calls signal for every change and returns when ctx is done or on errors
+type Notifier interface {
+	Run(ctx context.Context, signal func()) error
+}
+
+// ErrorLogger ...
+type ErrorLogger func(message string, err error)
+
+type fetchRequest struct {
+	limit        uint64
+	fromSequence uint64
+	result       []Event
+	responseChan chan fetchResponse
+}
+
+type fetchResponse struct {
+	existed bool
+	result  []Event
+}
+
+// Core sequences the events of a log and delivers them to the publishers in order of sequence
+type Core struct {
+	repo Repository
+
+	signalChan chan struct{}
+	listenChan chan Event
+	fetchChan  chan fetchRequest
+
+	// options
+	repoLimit     uint64
+	bufferSize    uint64
+	startupLimit  uint64
+	prefetchDepth int
+	errorTimeout  time.Duration
+	drainTimeout  time.Duration
+
+	publishers []Publisher
+	notifiers  []Notifier
+	logger     ErrorLogger
+
+	// sequences of the listener and of the checkpoints of publishers, for Pending
+	mut       sync.Mutex
+	sequence  uint64
+	published map[PublisherID]uint64
+}
+
+// NewCore ...
+func NewCore(repo Repository, options ...Option) *Core {
+	// copy, options append to the publishers and notifiers of opts
+	opts := *defaultCoreOpts
+	applyOptions(&opts, options...)
+
+	startupLimit := opts.startupLimit
+	if startupLimit == 0 {
+		startupLimit = opts.repoLimit
+	}
+
+	// the ring buffer holds the events loaded at startup, and at least a batch of the DB processor
+	bufferSize := opts.repoLimit
+	if startupLimit > bufferSize {
+		bufferSize = startupLimit
+	}
+
+	return &Core{
+		repo: repo,
+
+		signalChan: make(chan struct{}, opts.repoLimit),
+		listenChan: make(chan Event, opts.repoLimit),
+		fetchChan:  make(chan fetchRequest, opts.fetchLimit),
+
+		repoLimit:     opts.repoLimit,
+		bufferSize:    bufferSize,
+		startupLimit:  startupLimit,
+		prefetchDepth: opts.prefetchDepth,
+		errorTimeout:  opts.errorTimeout,
+		drainTimeout:  opts.drainTimeout,
+
+		publishers: opts.publishers,
+		notifiers:  opts.notifiers,
+		logger:     opts.logger,
+
+		published: make(map[PublisherID]uint64),
+	}
+}
+
+func (c *Core) runDBProcessor(ctx context.Context, lastEvents []Event) error {
+	lastSequence := uint64(0)
+	if len(lastEvents) > 0 {
+		lastSequence = lastEvents[len(lastEvents)-1].GetSequence()
+	}
+
+	for {
+		signalCount := uint64(0)
+
+		select {
+		case <-c.signalChan:
+			break
+		case <-time.After(c.errorTimeout):
+			break
+		case <-ctx.Done():
+			return nil
+		}
+		signalCount++
+
+		// drain all signals
+	DrainLoop:
+		for ; signalCount < c.repoLimit; signalCount++ {
+			select {
+			case <-c.signalChan:
+				continue DrainLoop
+			default:
+				break DrainLoop
+			}
+		}
+
+		events, err := c.repo.GetUnprocessedEvents(c.repoLimit + 1)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			continue
+		}
+
+		if uint64(len(events)) > c.repoLimit {
+			select {
+			case c.signalChan <- struct{}{}:
+				break
+			default:
+				break
+			}
+			events = events[:c.repoLimit]
+		}
+
+		for i := range events {
+			events[i] = events[i].WithSequence(lastSequence + uint64(i) + 1)
+		}
+		lastSequence += uint64(len(events))
+
+		err = c.repo.UpdateSequences(events)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range events {
+			c.listenChan <- e
+		}
+	}
+}
+
+func prepareFetchResponse(
+	events []Event, req fetchRequest,
+	sequence uint64, firstSequence uint64,
+	bufferSize uint64,
+) fetchResponse {
+	if req.fromSequence < firstSequence {
+		return fetchResponse{
+			existed: false,
+		}
+	}
+
+	if req.fromSequence+bufferSize < sequence+1 {
+		return fetchResponse{
+			existed: false,
+		}
+	}
+
+	result := req.result
+
+	top := sequence + 1
+	if top > req.fromSequence+req.limit {
+		top = req.fromSequence + req.limit
+	}
+
+	last := top % bufferSize
+	first := req.fromSequence % bufferSize
+
+	if last > first {
+		result = append(result, events[first:last]...)
+	} else {
+		result = append(result, events[first:]...)
+		result = append(result, events[:last]...)
+	}
+
+	return fetchResponse{
+		existed: true,
+		result:  result,
+	}
+}
+
+func (c *Core) runListener(ctx context.Context, lastEvents []Event) {
+	bufferSize := c.bufferSize
+	events := make([]Event, bufferSize)
+
+	waitingFetches := make([]fetchRequest, 0, 100)
+
+	for _, e := range lastEvents {
+		index := e.GetSequence() % bufferSize
+		events[index] = e
+	}
+
+	sequence := uint64(0)
+	firstSequence := uint64(1)
+	if len(lastEvents) > 0 {
+		n := len(lastEvents)
+		sequence = lastEvents[n-1].GetSequence()
+		firstSequence = lastEvents[0].GetSequence()
+	}
+	c.setSequence(sequence)
+
+	for {
+		select {
+		case event := <-c.listenChan:
+			sequence = event.GetSequence()
+			index := sequence % bufferSize
+			events[index] = event
+			c.setSequence(sequence)
+
+			for _, req := range waitingFetches {
+				res := prepareFetchResponse(events, req, sequence, firstSequence, bufferSize)
+				req.responseChan <- res
+			}
+			waitingFetches = waitingFetches[:0]
+
+		case req := <-c.fetchChan:
+			if req.fromSequence > sequence+1 {
+				panic("req.fromSequence > sequence + 1")
+			}
+			if req.fromSequence == sequence+1 {
+				waitingFetches = append(waitingFetches, req)
+			} else {
+				res := prepareFetchResponse(events, req, sequence, firstSequence, bufferSize)
+				req.responseChan <- res
+			}
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runPublisher stops fetching new batches when ctx is done,
+// a published batch has its checkpoint saved until drainCtx is done
+func (c *Core) runPublisher(ctx context.Context, drainCtx context.Context, p Publisher) {
+	var lastSequence uint64
+	for {
+		var err error
+		lastSequence, err = c.repo.GetLastSequence(p.GetID())
+		if err != nil {
+			c.logger("repo.GetLastSequence", err)
+			ok := sleepContext(ctx, c.errorTimeout)
+			if !ok {
+				return
+			}
+			continue
+		}
+		break
+	}
+	c.setPublished(p.GetID(), lastSequence)
+
+	reservedEvents := make([]Event, 0, c.repoLimit)
+	ch := make(chan fetchResponse, 1)
+	prefetch := newPrefetcher(c.repo, c.repoLimit, c.prefetchDepth)
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		req := fetchRequest{
+			limit:        c.repoLimit,
+			fromSequence: lastSequence + 1,
+			result:       reservedEvents,
+			responseChan: ch,
+		}
+
+		c.fetch(req)
+
+		var response fetchResponse
+		select {
+		case res := <-ch:
+			response = res
+		case <-ctx.Done():
+			return
+		}
+
+		if !response.existed {
+			events, err := prefetch.get(ctx, lastSequence+1)
+			if err != nil {
+				c.logger("repo.GetEventsFromSequence", err)
+				ok := sleepContext(ctx, c.errorTimeout)
+				if !ok {
+					return
+				}
+				continue
+			}
+			response.result = events
+		}
+
+		if len(response.result) == 0 {
+			continue
+		}
+
+		err := p.Publish(response.result)
+		if err != nil {
+			c.logger("p.Publish", err)
+			ok := sleepContext(ctx, c.errorTimeout)
+			if !ok {
+				return
+			}
+			continue
+		}
+
+		newSequence := response.result[len(response.result)-1].GetSequence()
+
+		// retrying only the checkpoint, publishing the batch again would deliver it twice
+		for {
+			err = c.repo.SaveLastSequence(p.GetID(), newSequence)
+			if err == nil {
+				break
+			}
+			c.logger("repo.SaveLastSequence", err)
+			ok := sleepContext(drainCtx, c.errorTimeout)
+			if !ok {
+				return
+			}
+		}
+
+		lastSequence = newSequence
+		c.setPublished(p.GetID(), lastSequence)
+	}
+}
+
+func (c *Core) runNotifier(ctx context.Context, n Notifier) {
+	for {
+		err := n.Run(ctx, c.notify)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.logger("n.Run", err)
+		}
+
+		ok := sleepContext(ctx, c.errorTimeout)
+		if !ok {
+			return
+		}
+	}
+}
+
+// runLoop returns a channel closed when all the publishers returned,
+// publishers still publishing after the drain timeout are left running
+func (c *Core) runLoop(ctx context.Context) <-chan struct{} {
+	publishersDone := make(chan struct{})
+
+	lastEvents, err := c.repo.GetLastEvents(c.startupLimit)
+	if err != nil {
+		c.logger("repo.GetLastEvents", err)
+		close(publishersDone)
+		return publishersDone
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the listener and the publishers keep running after ctx is done,
+	// until the publishers finished their batches or the drain timeout
+	drainCtx, drainCancel := context.WithCancel(context.Background())
+	defer drainCancel()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-drainCtx.Done():
+			return
+		}
+		ok := sleepContext(drainCtx, c.drainTimeout)
+		if ok {
+			drainCancel()
+		}
+	}()
+
+	var publisherWg sync.WaitGroup
+	publisherWg.Add(len(c.publishers))
+
+	var wg sync.WaitGroup
+	wg.Add(2 + len(c.notifiers))
+
+	go func() {
+		defer wg.Done()
+
+		err := c.runDBProcessor(ctx, lastEvents)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			c.logger("c.runDBProcessor", err)
+			cancel()
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		c.runListener(drainCtx, lastEvents)
+	}()
+
+	for _, p := range c.publishers {
+		publisher := p
+
+		go func() {
+			defer publisherWg.Done()
+
+			c.runPublisher(ctx, drainCtx, publisher)
+		}()
+	}
+
+	for _, n := range c.notifiers {
+		notifier := n
+
+		go func() {
+			defer wg.Done()
+
+			c.runNotifier(ctx, notifier)
+		}()
+	}
+
+	go func() {
+		publisherWg.Wait()
+		close(publishersDone)
+	}()
+
+	select {
+	case <-publishersDone:
+	case <-drainCtx.Done():
+		c.logger("c.runLoop", ErrDrainTimeout)
+	}
+	drainCancel()
+
+	wg.Wait()
+	return publishersDone
+}
+
+// Run ...
+func (c *Core) Run(ctx context.Context) {
+	for {
+		publishersDone := c.runLoop(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		// a publisher MUST NOT be started again while its previous Publish is still running
+		select {
+		case <-publishersDone:
+		case <-ctx.Done():
+			return
+		}
+
+		ok := sleepContext(ctx, c.errorTimeout)
+		if !ok {
+			return
+		}
+	}
+}
+
+// Pending returns for each publisher the number of sequenced events it has not published yet,
+// events not sequenced yet are not counted
+func (c *Core) Pending() map[PublisherID]uint64 {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+
+	result := make(map[PublisherID]uint64, len(c.publishers))
+	for _, p := range c.publishers {
+		published := c.published[p.GetID()]
+		if c.sequence > published {
+			result[p.GetID()] = c.sequence - published
+		} else {
+			result[p.GetID()] = 0
+		}
+	}
+	return result
+}
+
+func (c *Core) setSequence(seq uint64) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.sequence = seq
+}
+
+func (c *Core) setPublished(id PublisherID, seq uint64) {
+	c.mut.Lock()
+	defer c.mut.Unlock()
+	c.published[id] = seq
+}
+
+// Signal ...
+func (c *Core) Signal() {
+	c.signalChan <- struct{}{}
+}
+
+// notify is a non-blocking Signal, signals are coalesced by the DB processor anyway
+func (c *Core) notify() {
+	select {
+	case c.signalChan <- struct{}{}:
+	default:
+	}
+}
+
+func (c *Core) fetch(req fetchRequest) {
+	c.fetchChan <- req
+}
+
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
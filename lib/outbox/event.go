@@ -0,0 +1,61 @@
+package outbox
+
+import (
+	"database/sql"
+	"time"
+)
+
+// Event an event of a log, each aggregate has its own event type implementing it
+type Event interface {
+	// GetSequence returns the sequence of the event, zero when not sequenced yet
+	GetSequence() uint64
+
+	// WithSequence returns a copy of the event having the sequence seq
+	WithSequence(seq uint64) Event
+}
+
+// Record an event stored by SQLRepository, Data is the encoded event of the aggregate owning the log,
+// e.g. a marshalled protobuf message
+type Record struct {
+	ID        uint64
+	Sequence  uint64
+	Data      []byte
+	CreatedAt time.Time
+}
+
+var _ Event = Record{}
+
+// GetSequence ...
+func (r Record) GetSequence() uint64 {
+	return r.Sequence
+}
+
+// WithSequence ...
+func (r Record) WithSequence(seq uint64) Event {
+	r.Sequence = seq
+	return r
+}
+
+type eventRecord struct {
+	ID        uint64        `db:"id"`
+	Sequence  sql.NullInt64 `db:"sequence"`
+	Data      []byte        `db:"data"`
+	CreatedAt time.Time     `db:"created_at"`
+}
+
+func (r eventRecord) toEvent() Event {
+	return Record{
+		ID:        r.ID,
+		Sequence:  uint64(r.Sequence.Int64),
+		Data:      r.Data,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+func recordsToEvents(records []eventRecord) []Event {
+	result := make([]Event, 0, len(records))
+	for _, r := range records {
+		result = append(result, r.toEvent())
+	}
+	return result
+}
@@ -0,0 +1,94 @@
+package outbox
+
+import "time"
+
+// Option ...
+type Option func(opts *coreOpts)
+
+type coreOpts struct {
+	repoLimit     uint64
+	fetchLimit    uint64
+	startupLimit  uint64
+	prefetchDepth int
+
+	publishers   []Publisher
+	notifiers    []Notifier
+	errorTimeout time.Duration
+	drainTimeout time.Duration
+	logger       ErrorLogger
+}
+
+var defaultCoreOpts = &coreOpts{
+	repoLimit:     1000,
+	fetchLimit:    100,
+	prefetchDepth: 4,
+
+	errorTimeout: 1 * time.Minute,
+	drainTimeout: 10 * time.Second,
+	logger: func(message string, err error) {
+	},
+}
+
+// AddPublisher ...
+func AddPublisher(p Publisher) Option {
+	return func(opts *coreOpts) {
+		opts.publishers = append(opts.publishers, p)
+	}
+}
+
+// AddNotifier adds a source of change notifications, in addition to Signal
+func AddNotifier(n Notifier) Option {
+	return func(opts *coreOpts) {
+		opts.notifiers = append(opts.notifiers, n)
+	}
+}
+
+// WithRepositoryLimit ...
+func WithRepositoryLimit(limit uint64) Option {
+	return func(opts *coreOpts) {
+		opts.repoLimit = limit
+	}
+}
+
+// WithStartupLimit the number of last events loaded into the ring buffer when starting,
+// the repository limit by default, the ring buffer is enlarged to hold them when greater
+func WithStartupLimit(limit uint64) Option {
+	return func(opts *coreOpts) {
+		opts.startupLimit = limit
+	}
+}
+
+// WithPrefetchDepth the number of pages of events read concurrently
+// by a publisher lagging behind the ring buffer
+func WithPrefetchDepth(depth int) Option {
+	return func(opts *coreOpts) {
+		opts.prefetchDepth = depth
+	}
+}
+
+// WithErrorTimeout ...
+func WithErrorTimeout(d time.Duration) Option {
+	return func(opts *coreOpts) {
+		opts.errorTimeout = d
+	}
+}
+
+// WithDrainTimeout how long Run waits for publishers to finish their batches after ctx is done
+func WithDrainTimeout(d time.Duration) Option {
+	return func(opts *coreOpts) {
+		opts.drainTimeout = d
+	}
+}
+
+// WithErrorLogger ...
+func WithErrorLogger(logger ErrorLogger) Option {
+	return func(opts *coreOpts) {
+		opts.logger = logger
+	}
+}
+
+func applyOptions(opts *coreOpts, options ...Option) {
+	for _, o := range options {
+		o(opts)
+	}
+}
@@ -0,0 +1,96 @@
+// Package outbox implements the transactional outbox of an aggregate:
+// events are inserted in the same transaction as the aggregate changes,
+// then sequenced and delivered to the publishers by the core.
+// Each aggregate has its own tables, query set and Outbox, e.g.
+//
+//	var orderQueries = outbox.NewQueries(outbox.Tables{
+//		Events:     "order_events",
+//		Publishers: "order_publishers",
+//	})
+//
+// The events of an Outbox are Records, the event type of the aggregate is encoded to Record.Data.
+// Outbox, SQLRepository and Queries are MySQL only: the queries use ? placeholders and ON DUPLICATE KEY UPDATE.
+// An aggregate with its own event type or another storage, like the todo events on Postgres and SQLite,
+// implements Event and Repository, and runs a Core directly
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+)
+
+// Outbox an event log with its publisher pipeline
+type Outbox struct {
+	db   *sqlx.DB
+	repo *SQLRepository
+	core *Core
+}
+
+// Writer inserts events inside a transaction of Outbox.Transact
+type Writer struct {
+	tx    *sqlx.Tx
+	repo  *SQLRepository
+	count int
+}
+
+// New creates an Outbox of the tables of queries, options are passed to the core, db MUST be a MySQL database
+func New(db *sqlx.DB, queries *Queries, options ...Option) *Outbox {
+	repo := NewSQLRepository(db, queries)
+	return &Outbox{
+		db:   db,
+		repo: repo,
+		core: NewCore(repo, options...),
+	}
+}
+
+// Insert inserts an event with data
+func (w *Writer) Insert(ctx context.Context, data []byte) (uint64, error) {
+	id, err := w.repo.InsertEvent(ctx, w.tx, data)
+	if err != nil {
+		return 0, err
+	}
+	w.count++
+	return id, nil
+}
+
+// Transact runs fn in a transaction, rollbacks when fn returns an error.
+// The core is signalled after committing the events inserted by fn
+func (o *Outbox) Transact(ctx context.Context, fn func(tx *sqlx.Tx, w *Writer) error) error {
+	tx, err := o.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	w := &Writer{tx: tx, repo: o.repo}
+	err = fn(tx, w)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return err
+	}
+
+	if w.count > 0 {
+		o.core.Signal()
+	}
+	return nil
+}
+
+// Signal wakes up the core, for events inserted by other processes
+func (o *Outbox) Signal() {
+	o.core.Signal()
+}
+
+// Core returns the core, e.g. for serving the Signal RPC
+func (o *Outbox) Core() *Core {
+	return o.core
+}
+
+// Run runs the core until ctx is done
+func (o *Outbox) Run(ctx context.Context) {
+	o.core.Run(ctx)
+}
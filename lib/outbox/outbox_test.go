@@ -0,0 +1,197 @@
+package outbox_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+	"todoapp/lib/outbox"
+)
+
+var testQueries = outbox.NewQueries(outbox.Tables{
+	Events:     "order_events",
+	Publishers: "order_publishers",
+})
+
+func TestNewQueries(t *testing.T) {
+	assert.Equal(t, outbox.Tables{
+		Events:     "order_events",
+		Publishers: "order_publishers",
+	}, testQueries.Tables())
+}
+
+func TestNewQueries_InvalidTableName(t *testing.T) {
+	assert.Panics(t, func() {
+		outbox.NewQueries(outbox.Tables{
+			Events:     "order_events; DROP TABLE todos",
+			Publishers: "order_publishers",
+		})
+	})
+}
+
+type memoryRepository struct {
+	mut        sync.Mutex
+	events     []outbox.Record
+	publishers map[outbox.PublisherID]uint64
+
+	fromSequenceCalls int
+}
+
+var _ outbox.Repository = &memoryRepository{}
+
+func (r *memoryRepository) insert(data []byte) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.events = append(r.events, outbox.Record{ID: uint64(len(r.events) + 1), Data: data})
+}
+
+func (r *memoryRepository) sequenced() []outbox.Event {
+	var result []outbox.Event
+	for _, e := range r.events {
+		if e.Sequence != 0 {
+			result = append(result, e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetSequence() < result[j].GetSequence()
+	})
+	return result
+}
+
+func (r *memoryRepository) GetLastEvents(limit uint64) ([]outbox.Event, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	events := r.sequenced()
+	if uint64(len(events)) > limit {
+		events = events[uint64(len(events))-limit:]
+	}
+	return events, nil
+}
+
+func (r *memoryRepository) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]outbox.Event, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.fromSequenceCalls++
+
+	var result []outbox.Event
+	for _, e := range r.sequenced() {
+		if e.GetSequence() >= seq && uint64(len(result)) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) GetUnprocessedEvents(limit uint64) ([]outbox.Event, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	var result []outbox.Event
+	for _, e := range r.events {
+		if e.Sequence == 0 && uint64(len(result)) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func (r *memoryRepository) GetLastSequence(id outbox.PublisherID) (uint64, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	return r.publishers[id], nil
+}
+
+func (r *memoryRepository) SaveLastSequence(id outbox.PublisherID, seq uint64) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.publishers[id] = seq
+	return nil
+}
+
+func (r *memoryRepository) UpdateSequences(events []outbox.Event) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	for _, e := range events {
+		record := e.(outbox.Record)
+		r.events[record.ID-1].Sequence = record.Sequence
+	}
+	return nil
+}
+
+type collectPublisher struct {
+	events chan []outbox.Event
+}
+
+func (p *collectPublisher) GetID() outbox.PublisherID {
+	return 1
+}
+
+func (p *collectPublisher) Publish(events []outbox.Event) error {
+	// the core reuses the slice of events
+	p.events <- append([]outbox.Event(nil), events...)
+	return nil
+}
+
+func TestCore_Publish(t *testing.T) {
+	repo := &memoryRepository{publishers: make(map[outbox.PublisherID]uint64)}
+	publisher := &collectPublisher{events: make(chan []outbox.Event, 10)}
+
+	core := outbox.NewCore(repo,
+		outbox.WithErrorTimeout(time.Second),
+		outbox.AddPublisher(publisher),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go core.Run(ctx)
+
+	repo.insert([]byte("order created"))
+	core.Signal()
+
+	events := <-publisher.events
+	assert.Equal(t, 1, len(events))
+	record := events[0].(outbox.Record)
+	assert.Equal(t, uint64(1), record.Sequence)
+	assert.Equal(t, []byte("order created"), record.Data)
+}
+
+func TestCore_StartupLimit_GreaterThanRepositoryLimit(t *testing.T) {
+	repo := &memoryRepository{publishers: make(map[outbox.PublisherID]uint64)}
+	for i := 0; i < 5; i++ {
+		repo.insert([]byte("order created"))
+		repo.events[i].Sequence = uint64(i + 1)
+	}
+	publisher := &collectPublisher{events: make(chan []outbox.Event, 10)}
+
+	core := outbox.NewCore(repo,
+		outbox.WithRepositoryLimit(2),
+		outbox.WithStartupLimit(5),
+		outbox.WithErrorTimeout(time.Second),
+		outbox.AddPublisher(publisher),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go core.Run(ctx)
+
+	var sequences []uint64
+	for len(sequences) < 5 {
+		for _, e := range <-publisher.events {
+			sequences = append(sequences, e.GetSequence())
+		}
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, sequences)
+
+	// all the events are served from the ring buffer
+	repo.mut.Lock()
+	defer repo.mut.Unlock()
+	assert.Equal(t, 0, repo.fromSequenceCalls)
+}
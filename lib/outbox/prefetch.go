@@ -0,0 +1,97 @@
+package outbox
+
+import "context"
+
+type prefetchResult struct {
+	events []Event
+	err    error
+}
+
+type prefetchPage struct {
+	from   uint64
+	result chan prefetchResult
+	cancel context.CancelFunc
+}
+
+// prefetcher reads the pages of events after a sequence concurrently,
+// used by a publisher lagging behind the ring buffer of the listener,
+// so catching up is not bounded by the round trips of reading one page at a time
+type prefetcher struct {
+	repo  Repository
+	limit uint64
+	depth int
+
+	pages []prefetchPage
+	next  uint64
+}
+
+func newPrefetcher(repo Repository, limit uint64, depth int) *prefetcher {
+	if depth < 1 {
+		depth = 1
+	}
+	return &prefetcher{
+		repo:  repo,
+		limit: limit,
+		depth: depth,
+	}
+}
+
+// get returns the events with sequences in [from, from + limit), requesting the next pages in advance
+func (p *prefetcher) get(ctx context.Context, from uint64) ([]Event, error) {
+	if len(p.pages) == 0 || p.pages[0].from != from {
+		p.reset(from)
+	}
+	p.fill(ctx)
+
+	page := p.pages[0]
+	p.pages = p.pages[1:]
+
+	res := <-page.result
+	page.cancel()
+	if res.err != nil {
+		p.reset(0)
+		return nil, res.err
+	}
+
+	events := res.events
+	for i, e := range events {
+		if e.GetSequence() >= from+p.limit {
+			events = events[:i]
+			break
+		}
+	}
+
+	// the next pages are empty or start after a gap
+	if uint64(len(events)) < p.limit {
+		p.reset(0)
+	}
+	return events, nil
+}
+
+// reset cancels the queries of the pages in flight and drops them,
+// their results are buffered so the requests do not block
+func (p *prefetcher) reset(from uint64) {
+	for _, page := range p.pages {
+		page.cancel()
+	}
+	p.pages = p.pages[:0]
+	p.next = from
+}
+
+func (p *prefetcher) fill(ctx context.Context) {
+	for len(p.pages) < p.depth {
+		pageCtx, cancel := context.WithCancel(ctx)
+		page := prefetchPage{
+			from:   p.next,
+			result: make(chan prefetchResult, 1),
+			cancel: cancel,
+		}
+		p.next += p.limit
+		p.pages = append(p.pages, page)
+
+		go func() {
+			events, err := p.repo.GetEventsFromSequence(pageCtx, page.from, p.limit)
+			page.result <- prefetchResult{events: events, err: err}
+		}()
+	}
+}
@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+type prefetchRepo struct {
+	Repository
+
+	mut       sync.Mutex
+	sequences []uint64
+	calls     []uint64
+	err       error
+	block     bool
+	cancelled []uint64
+}
+
+func (r *prefetchRepo) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]Event, error) {
+	if r.block {
+		<-ctx.Done()
+		r.mut.Lock()
+		r.cancelled = append(r.cancelled, seq)
+		r.mut.Unlock()
+		return nil, ctx.Err()
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.calls = append(r.calls, seq)
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	var result []Event
+	for _, s := range r.sequences {
+		if uint64(len(result)) >= limit {
+			break
+		}
+		if s >= seq {
+			result = append(result, Record{Sequence: s})
+		}
+	}
+	return result, nil
+}
+
+func (r *prefetchRepo) getCalls() []uint64 {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	return append([]uint64(nil), r.calls...)
+}
+
+func sequenceRange(from uint64, to uint64) []uint64 {
+	var result []uint64
+	for s := from; s <= to; s++ {
+		result = append(result, s)
+	}
+	return result
+}
+
+func prefetchSequences(events []Event) []uint64 {
+	var result []uint64
+	for _, e := range events {
+		result = append(result, e.GetSequence())
+	}
+	return result
+}
+
+func TestPrefetcher_Pages(t *testing.T) {
+	repo := &prefetchRepo{sequences: sequenceRange(1, 7)}
+	p := newPrefetcher(repo, 3, 2)
+
+	events, err := p.get(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, prefetchSequences(events))
+
+	events, err = p.get(context.Background(), 4)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{4, 5, 6}, prefetchSequences(events))
+
+	events, err = p.get(context.Background(), 7)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{7}, prefetchSequences(events))
+
+	// the page from 10 may still be in flight
+	assert.Subset(t, repo.getCalls(), []uint64{1, 4, 7})
+}
+
+func TestPrefetcher_Gap(t *testing.T) {
+	repo := &prefetchRepo{sequences: append(sequenceRange(1, 2), sequenceRange(5, 8)...)}
+	p := newPrefetcher(repo, 3, 2)
+
+	events, err := p.get(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2}, prefetchSequences(events))
+
+	events, err = p.get(context.Background(), 3)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{5}, prefetchSequences(events))
+}
+
+func TestPrefetcher_Error(t *testing.T) {
+	repo := &prefetchRepo{sequences: sequenceRange(1, 7), err: errors.New("some error")}
+	p := newPrefetcher(repo, 3, 2)
+
+	events, err := p.get(context.Background(), 1)
+	assert.Equal(t, errors.New("some error"), err)
+	assert.Nil(t, events)
+
+	repo.mut.Lock()
+	repo.err = nil
+	repo.mut.Unlock()
+
+	events, err = p.get(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, prefetchSequences(events))
+}
+
+func TestPrefetcher_Reset_CancelsPages(t *testing.T) {
+	repo := &prefetchRepo{block: true}
+	p := newPrefetcher(repo, 3, 3)
+
+	p.fill(context.Background())
+	p.reset(0)
+
+	assert.Eventually(t, func() bool {
+		repo.mut.Lock()
+		defer repo.mut.Unlock()
+		return len(repo.cancelled) == 3
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"fmt"
+	"regexp"
+	"todoapp/lib/dblib"
+)
+
+// Tables the names of the tables of an event log, both MUST have the same schema as
+// todo_events and todo_publishers in migrations (MySQL)
+type Tables struct {
+	Events     string
+	Publishers string
+}
+
+// Queries the SQL queries of an event log, written for MySQL only
+type Queries struct {
+	tables Tables
+
+	getLastEvents         string
+	getEventsFromSequence string
+	getUnprocessedEvents  string
+	getLastSequence       string
+	saveLastSequence      string
+	updateSequences       string
+	insertEvent           string
+}
+
+var tableNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// NewQueries creates and registers the queries of the tables.
+// Registering uses dblib, MUST be called in package level var declarations
+func NewQueries(tables Tables) *Queries {
+	for _, name := range []string{tables.Events, tables.Publishers} {
+		if !tableNameRegexp.MatchString(name) {
+			panic(fmt.Sprintf("invalid table name: %q", name))
+		}
+	}
+
+	q := &Queries{
+		tables: tables,
+
+		getLastEvents: dblib.NewQuery(fmt.Sprintf(`
+SELECT e.id, e.sequence, e.data, e.created_at FROM (
+	SELECT id, sequence, data, created_at FROM %s
+	WHERE sequence IS NOT NULL
+	ORDER BY sequence DESC
+	LIMIT ?
+) e ORDER BY sequence ASC
+`, tables.Events)),
+
+		getEventsFromSequence: dblib.NewQuery(fmt.Sprintf(`
+SELECT id, sequence, data, created_at FROM %s
+WHERE sequence IS NOT NULL AND sequence >= ?
+ORDER BY sequence ASC
+LIMIT ?
+`, tables.Events)),
+
+		getUnprocessedEvents: dblib.NewQuery(fmt.Sprintf(`
+SELECT id, data, created_at FROM %s
+WHERE sequence IS NULL
+ORDER BY id ASC
+LIMIT ?
+`, tables.Events)),
+
+		getLastSequence: dblib.NewQuery(fmt.Sprintf(`
+SELECT sequence FROM %s
+WHERE id = ?
+`, tables.Publishers)),
+
+		saveLastSequence: dblib.NewQuery(fmt.Sprintf(`
+INSERT INTO %s (id, sequence)
+VALUES (?, ?) AS new
+ON DUPLICATE KEY UPDATE sequence = new.sequence
+`, tables.Publishers)),
+
+		updateSequences: fmt.Sprintf(`
+INSERT INTO %s (id, sequence, data)
+VALUES %%s AS new
+ON DUPLICATE KEY UPDATE sequence = new.sequence
+`, tables.Events),
+
+		insertEvent: dblib.NewQuery(fmt.Sprintf(`
+INSERT INTO %s (data) VALUES (?)
+`, tables.Events)),
+	}
+
+	_ = dblib.NewQuery(fmt.Sprintf(q.updateSequences, "(?, ?, '')"))
+	return q
+}
+
+// Tables returns the tables of the queries
+func (q *Queries) Tables() Tables {
+	return q.tables
+}
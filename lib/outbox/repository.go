@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+// SQLRepository the Repository of an event log stored in MySQL, the only dialect of Queries,
+// other storages implement Repository themselves
+type SQLRepository struct {
+	db      *sqlx.DB
+	queries *Queries
+}
+
+var _ Repository = &SQLRepository{}
+
+// NewSQLRepository ...
+func NewSQLRepository(db *sqlx.DB, queries *Queries) *SQLRepository {
+	return &SQLRepository{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// GetLastEvents ...
+func (r *SQLRepository) GetLastEvents(limit uint64) ([]Event, error) {
+	var records []eventRecord
+	err := r.db.Select(&records, r.queries.getLastEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	return recordsToEvents(records), nil
+}
+
+// GetEventsFromSequence ...
+func (r *SQLRepository) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]Event, error) {
+	var records []eventRecord
+	err := r.db.SelectContext(ctx, &records, r.queries.getEventsFromSequence, seq, limit)
+	if err != nil {
+		return nil, err
+	}
+	return recordsToEvents(records), nil
+}
+
+// GetUnprocessedEvents ...
+func (r *SQLRepository) GetUnprocessedEvents(limit uint64) ([]Event, error) {
+	var records []eventRecord
+	err := r.db.Select(&records, r.queries.getUnprocessedEvents, limit)
+	if err != nil {
+		return nil, err
+	}
+	return recordsToEvents(records), nil
+}
+
+// GetLastSequence ...
+func (r *SQLRepository) GetLastSequence(id PublisherID) (uint64, error) {
+	var result uint64
+	err := r.db.Get(&result, r.queries.getLastSequence, id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// SaveLastSequence ...
+func (r *SQLRepository) SaveLastSequence(id PublisherID, seq uint64) error {
+	_, err := r.db.Exec(r.queries.saveLastSequence, id, seq)
+	return err
+}
+
+// UpdateSequences the events MUST be the Records returned by the repository
+func (r *SQLRepository) UpdateSequences(events []Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var buf strings.Builder
+	args := make([]interface{}, 0, 2*len(events))
+	for i, e := range events {
+		if i == 0 {
+			buf.WriteString("(?, ?, '')")
+		} else {
+			buf.WriteString(",(?, ?, '')")
+		}
+		r := e.(Record)
+		args = append(args, r.ID, r.Sequence)
+	}
+
+	query := fmt.Sprintf(r.queries.updateSequences, buf.String())
+	_, err := r.db.Exec(query, args...)
+	return err
+}
+
+// InsertEvent inserts an event inside the transaction of the aggregate changes
+func (r *SQLRepository) InsertEvent(ctx context.Context, tx *sqlx.Tx, data []byte) (uint64, error) {
+	res, err := tx.ExecContext(ctx, r.queries.insertEvent, data)
+	if err != nil {
+		return 0, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return uint64(id), nil
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"net/url"
+	"strings"
+)
+
+// OptionConfig for options
+type OptionConfig struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+}
+
+// Config for configuring PostgreSQL
+type Config struct {
+	Host         string         `mapstructure:"host"`
+	Port         uint16         `mapstructure:"port"`
+	Database     string         `mapstructure:"database"`
+	Username     string         `mapstructure:"username"`
+	Password     string         `mapstructure:"password"`
+	MaxOpenConns int            `mapstructure:"max_open_conns"`
+	MaxIdleConns int            `mapstructure:"max_idle_conns"`
+	Options      []OptionConfig `mapstructure:"options"`
+}
+
+// DefaultConfig default values
+var DefaultConfig = Config{
+	Host:         "localhost",
+	Port:         5432,
+	Username:     "username",
+	Password:     "password",
+	Database:     "sample",
+	MaxOpenConns: 20,
+	MaxIdleConns: 5,
+	Options: []OptionConfig{
+		{Key: "sslmode", Value: "disable"},
+	},
+}
+
+// DSN returns data source name in URL form, also used by golang-migrate
+func (c Config) DSN() string {
+	var opts []string
+	for _, o := range c.Options {
+		key := url.QueryEscape(o.Key)
+		value := url.QueryEscape(o.Value)
+		opts = append(opts, key+"="+value)
+	}
+	optStr := strings.Join(opts, "&")
+
+	user := url.UserPassword(c.Username, c.Password)
+	return fmt.Sprintf("postgres://%s@%s:%d/%s?%s", user.String(), c.Host, c.Port, c.Database, optStr)
+}
+
+// MustConnect connects to database using sqlx
+// MUST import
+// _ "github.com/lib/pq"
+// to use in main.go
+func MustConnect(conf Config) *sqlx.DB {
+	db := sqlx.MustConnect("postgres", conf.DSN())
+
+	db.SetMaxOpenConns(conf.MaxOpenConns)
+	db.SetMaxIdleConns(conf.MaxIdleConns)
+	return db
+}
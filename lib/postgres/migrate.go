@@ -0,0 +1,14 @@
+package postgres
+
+// MUST import in main.go to run
+// _ "github.com/golang-migrate/migrate/v4/database/postgres"
+// _ "github.com/golang-migrate/migrate/v4/source/file"
+import (
+	"github.com/spf13/cobra"
+	"todoapp/lib/dblib"
+)
+
+// MigrateCommand the command for migration, scripts are in migrations/postgres
+func MigrateCommand(conf Config) *cobra.Command {
+	return dblib.MigrateCommand(conf.DSN(), "migrations/postgres")
+}
@@ -0,0 +1,57 @@
+package sqlite
+
+import (
+	"github.com/jmoiron/sqlx"
+	"net/url"
+	"strings"
+)
+
+// OptionConfig for options
+type OptionConfig struct {
+	Key   string `mapstructure:"key"`
+	Value string `mapstructure:"value"`
+}
+
+// Config for configuring SQLite
+type Config struct {
+	Path         string         `mapstructure:"path"`
+	MaxOpenConns int            `mapstructure:"max_open_conns"`
+	Options      []OptionConfig `mapstructure:"options"`
+}
+
+// DefaultConfig default values
+var DefaultConfig = Config{
+	Path:         "todoapp.db",
+	MaxOpenConns: 4,
+	Options: []OptionConfig{
+		{Key: "_journal_mode", Value: "WAL"},
+		{Key: "_busy_timeout", Value: "5000"},
+		{Key: "_txlock", Value: "immediate"},
+	},
+}
+
+func (c Config) query() string {
+	var opts []string
+	for _, o := range c.Options {
+		key := url.QueryEscape(o.Key)
+		value := url.QueryEscape(o.Value)
+		opts = append(opts, key+"="+value)
+	}
+	return strings.Join(opts, "&")
+}
+
+// DSN returns data source name
+func (c Config) DSN() string {
+	return "file:" + c.Path + "?" + c.query()
+}
+
+// MustConnect connects to database using sqlx
+// MUST import
+// _ "github.com/mattn/go-sqlite3"
+// to use in main.go
+func MustConnect(conf Config) *sqlx.DB {
+	db := sqlx.MustConnect("sqlite3", conf.DSN())
+
+	db.SetMaxOpenConns(conf.MaxOpenConns)
+	return db
+}
@@ -0,0 +1,18 @@
+package sqlite
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestConfig_DSN(t *testing.T) {
+	s := DefaultConfig.DSN()
+	expected := "file:todoapp.db?_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate"
+	assert.Equal(t, expected, s)
+}
+
+func TestConfig_MigrateURL(t *testing.T) {
+	s := DefaultConfig.migrateURL()
+	expected := "sqlite3://todoapp.db?_journal_mode=WAL&_busy_timeout=5000&_txlock=immediate"
+	assert.Equal(t, expected, s)
+}
@@ -0,0 +1,18 @@
+package sqlite
+
+// MUST import in main.go to run
+// _ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+// _ "github.com/golang-migrate/migrate/v4/source/file"
+import (
+	"github.com/spf13/cobra"
+	"todoapp/lib/dblib"
+)
+
+func (c Config) migrateURL() string {
+	return "sqlite3://" + c.Path + "?" + c.query()
+}
+
+// MigrateCommand the command for migration, scripts are in migrations/sqlite
+func MigrateCommand(conf Config) *cobra.Command {
+	return dblib.MigrateCommand(conf.migrateURL(), "migrations/sqlite")
+}
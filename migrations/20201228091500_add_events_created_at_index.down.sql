@@ -0,0 +1 @@
+DROP INDEX idx_created_at ON todo_events;
@@ -0,0 +1,2 @@
+DROP TABLE todo_summaries;
+DROP TABLE todo_projection_checkpoints;
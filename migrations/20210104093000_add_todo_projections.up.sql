@@ -0,0 +1,19 @@
+CREATE TABLE todo_projection_checkpoints
+(
+    name       VARCHAR(64) PRIMARY KEY,
+    sequence   BIGINT UNSIGNED NOT NULL,
+    created_at TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP       NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+CREATE TABLE todo_summaries
+(
+    id            INT UNSIGNED PRIMARY KEY,
+    name          VARCHAR(255)    NOT NULL,
+    item_count    INT UNSIGNED    NOT NULL,
+    last_sequence BIGINT UNSIGNED NOT NULL,
+    created_at    TIMESTAMP       NOT NULL,
+    updated_at    TIMESTAMP       NOT NULL
+);
+
+CREATE INDEX idx_updated_at ON todo_summaries (updated_at);
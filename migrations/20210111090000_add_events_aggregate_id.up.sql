@@ -0,0 +1,3 @@
+ALTER TABLE todo_events ADD COLUMN aggregate_id BIGINT UNSIGNED NULL AFTER id;
+
+CREATE INDEX idx_aggregate_id ON todo_events (aggregate_id, id);
@@ -0,0 +1,9 @@
+DELETE FROM todo_publishers WHERE partition_id <> 0;
+ALTER TABLE todo_publishers DROP PRIMARY KEY, ADD PRIMARY KEY (id);
+ALTER TABLE todo_publishers DROP COLUMN partition_id;
+
+DROP INDEX idx_partition_sequence ON todo_events;
+DROP INDEX idx_sequence ON todo_events;
+CREATE UNIQUE INDEX idx_sequence ON todo_events (sequence);
+
+ALTER TABLE todo_events DROP COLUMN partition_id;
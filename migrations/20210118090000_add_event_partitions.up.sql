@@ -0,0 +1,8 @@
+ALTER TABLE todo_events ADD COLUMN partition_id INT UNSIGNED NOT NULL DEFAULT 0 AFTER aggregate_id;
+
+DROP INDEX idx_sequence ON todo_events;
+CREATE INDEX idx_sequence ON todo_events (sequence);
+CREATE UNIQUE INDEX idx_partition_sequence ON todo_events (partition_id, sequence);
+
+ALTER TABLE todo_publishers ADD COLUMN partition_id INT UNSIGNED NOT NULL DEFAULT 0 AFTER id;
+ALTER TABLE todo_publishers DROP PRIMARY KEY, ADD PRIMARY KEY (id, partition_id);
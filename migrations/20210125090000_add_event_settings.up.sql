@@ -0,0 +1,4 @@
+CREATE TABLE todo_event_settings (
+    id TINYINT UNSIGNED NOT NULL PRIMARY KEY,
+    partition_count INT UNSIGNED NOT NULL
+);
@@ -0,0 +1 @@
+UPDATE todo_events SET partition_id = 0 WHERE sequence IS NULL;
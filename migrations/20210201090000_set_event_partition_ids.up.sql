@@ -0,0 +1,4 @@
+UPDATE todo_events e
+    JOIN todo_event_settings s ON s.id = 1
+SET e.partition_id = e.aggregate_id % s.partition_count
+WHERE e.sequence IS NULL AND e.aggregate_id IS NOT NULL;
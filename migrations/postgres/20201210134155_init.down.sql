@@ -0,0 +1,3 @@
+DROP TABLE todo_items;
+
+DROP TABLE todos;
@@ -0,0 +1,17 @@
+CREATE TABLE todo_events
+(
+    id         BIGSERIAL PRIMARY KEY,
+    sequence   BIGINT      NULL,
+    data       BYTEA       NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_sequence ON todo_events (sequence);
+
+CREATE TABLE todo_publishers
+(
+    id         BIGINT PRIMARY KEY,
+    sequence   BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+DROP INDEX idx_aggregate_id;
+
+ALTER TABLE todo_events DROP COLUMN aggregate_id;
@@ -0,0 +1,3 @@
+DELETE FROM todo_publishers WHERE partition_id <> 0;
+ALTER TABLE todo_publishers DROP CONSTRAINT todo_publishers_pkey, ADD PRIMARY KEY (id);
+ALTER TABLE todo_publishers DROP COLUMN partition_id;
@@ -0,0 +1,3 @@
+-- events are only partitioned on mysql, the publishers have the same key on every database
+ALTER TABLE todo_publishers ADD COLUMN partition_id INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE todo_publishers DROP CONSTRAINT todo_publishers_pkey, ADD PRIMARY KEY (id, partition_id);
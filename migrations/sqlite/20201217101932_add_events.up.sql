@@ -0,0 +1,17 @@
+CREATE TABLE todo_events
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    sequence   INTEGER   NULL,
+    data       BLOB      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_sequence ON todo_events (sequence);
+
+CREATE TABLE todo_publishers
+(
+    id         INTEGER PRIMARY KEY,
+    sequence   INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
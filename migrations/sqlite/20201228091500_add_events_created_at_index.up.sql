@@ -0,0 +1 @@
+CREATE INDEX idx_created_at ON todo_events (created_at);
@@ -0,0 +1,21 @@
+-- SQLite can NOT drop columns, the table is rebuilt without aggregate_id
+DROP INDEX idx_aggregate_id;
+
+CREATE TABLE todo_events_old
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    sequence   INTEGER   NULL,
+    data       BLOB      NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO todo_events_old (id, sequence, data, created_at)
+SELECT id, sequence, data, created_at FROM todo_events;
+
+DROP TABLE todo_events;
+
+ALTER TABLE todo_events_old RENAME TO todo_events;
+
+CREATE UNIQUE INDEX idx_sequence ON todo_events (sequence);
+
+CREATE INDEX idx_created_at ON todo_events (created_at);
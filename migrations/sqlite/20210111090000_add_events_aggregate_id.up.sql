@@ -0,0 +1,3 @@
+ALTER TABLE todo_events ADD COLUMN aggregate_id INTEGER NULL;
+
+CREATE INDEX idx_aggregate_id ON todo_events (aggregate_id, id);
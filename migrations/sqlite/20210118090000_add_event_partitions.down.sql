@@ -0,0 +1,16 @@
+-- SQLite can NOT drop columns, the table is rebuilt without partition_id
+CREATE TABLE todo_publishers_old
+(
+    id         INTEGER PRIMARY KEY,
+    sequence   INTEGER   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO todo_publishers_old (id, sequence, created_at, updated_at)
+SELECT id, sequence, created_at, updated_at FROM todo_publishers
+WHERE partition_id = 0;
+
+DROP TABLE todo_publishers;
+
+ALTER TABLE todo_publishers_old RENAME TO todo_publishers;
@@ -0,0 +1,18 @@
+-- events are only partitioned on mysql, the publishers have the same key on every database.
+-- SQLite can NOT change the primary key, the table is rebuilt with partition_id
+CREATE TABLE todo_publishers_new
+(
+    id           INTEGER   NOT NULL,
+    partition_id INTEGER   NOT NULL DEFAULT 0,
+    sequence     INTEGER   NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id, partition_id)
+);
+
+INSERT INTO todo_publishers_new (id, partition_id, sequence, created_at, updated_at)
+SELECT id, 0, sequence, created_at, updated_at FROM todo_publishers;
+
+DROP TABLE todo_publishers;
+
+ALTER TABLE todo_publishers_new RENAME TO todo_publishers;
@@ -5,6 +5,10 @@ import (
 	liberrors "todoapp/lib/errors"
 )
 
+import (
+	"time"
+)
+
 // ErrGeneralInternalErrorAccessingDatabase ...
 type ErrGeneralInternalErrorAccessingDatabase liberrors.Error
 
@@ -14,6 +18,11 @@ func NewErrGeneralInternalErrorAccessingDatabase() *ErrGeneralInternalErrorAcces
 		RPCStatus: 13,
 		Code:      "1301",
 		Message:   "Error accessing database",
+		Messages: map[string]string{
+			"vi": "Lỗi truy cập cơ sở dữ liệu",
+		},
+		Retryable:  true,
+		RetryAfter: 1 * time.Second,
 	}
 }
 
@@ -22,6 +31,24 @@ func (e *ErrGeneralInternalErrorAccessingDatabase) Err() error {
 	return (*liberrors.Error)(e)
 }
 
+// Is ...
+func (e *ErrGeneralInternalErrorAccessingDatabase) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrGeneralInternalErrorAccessingDatabase) From(err error) (*ErrGeneralInternalErrorAccessingDatabase, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrGeneralInternalErrorAccessingDatabase)(domainErr), true
+}
+
 // ErrGeneralUnknown ...
 type ErrGeneralUnknown liberrors.Error
 
@@ -31,6 +58,9 @@ func NewErrGeneralUnknown() *ErrGeneralUnknown {
 		RPCStatus: 2,
 		Code:      "02",
 		Message:   "Unknown",
+		Messages: map[string]string{
+			"vi": "Lỗi không xác định",
+		},
 	}
 }
 
@@ -39,6 +69,24 @@ func (e *ErrGeneralUnknown) Err() error {
 	return (*liberrors.Error)(e)
 }
 
+// Is ...
+func (e *ErrGeneralUnknown) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrGeneralUnknown) From(err error) (*ErrGeneralUnknown, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrGeneralUnknown)(domainErr), true
+}
+
 // GeneralTag ...
 type GeneralTag struct {
 	InternalErrorAccessingDatabase *ErrGeneralInternalErrorAccessingDatabase
@@ -60,6 +108,9 @@ func NewErrTodoInvalidArgumentEmptyItems() *ErrTodoInvalidArgumentEmptyItems {
 		RPCStatus: 3,
 		Code:      "0301",
 		Message:   "Todo items must not be empty",
+		Messages: map[string]string{
+			"vi": "Danh sách mục của todo không được để trống",
+		},
 	}
 }
 
@@ -68,6 +119,74 @@ func (e *ErrTodoInvalidArgumentEmptyItems) Err() error {
 	return (*liberrors.Error)(e)
 }
 
+// Is ...
+func (e *ErrTodoInvalidArgumentEmptyItems) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrTodoInvalidArgumentEmptyItems) From(err error) (*ErrTodoInvalidArgumentEmptyItems, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrTodoInvalidArgumentEmptyItems)(domainErr), true
+}
+
+// WithViolation ...
+func (e *ErrTodoInvalidArgumentEmptyItems) WithViolation(field string, description string) *ErrTodoInvalidArgumentEmptyItems {
+	err := (*liberrors.Error)(e)
+	return (*ErrTodoInvalidArgumentEmptyItems)(err.WithViolation(field, description))
+}
+
+// ErrTodoInvalidArgumentRestorePoint ...
+type ErrTodoInvalidArgumentRestorePoint liberrors.Error
+
+// NewErrTodoInvalidArgumentRestorePoint ...
+func NewErrTodoInvalidArgumentRestorePoint() *ErrTodoInvalidArgumentRestorePoint {
+	return &ErrTodoInvalidArgumentRestorePoint{
+		RPCStatus: 3,
+		Code:      "0302",
+		Message:   "Exactly one of sequence and time must be set",
+		Messages: map[string]string{
+			"vi": "Phải đặt đúng một trong hai giá trị sequence hoặc time",
+		},
+	}
+}
+
+// Err ...
+func (e *ErrTodoInvalidArgumentRestorePoint) Err() error {
+	return (*liberrors.Error)(e)
+}
+
+// Is ...
+func (e *ErrTodoInvalidArgumentRestorePoint) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrTodoInvalidArgumentRestorePoint) From(err error) (*ErrTodoInvalidArgumentRestorePoint, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrTodoInvalidArgumentRestorePoint)(domainErr), true
+}
+
+// WithViolation ...
+func (e *ErrTodoInvalidArgumentRestorePoint) WithViolation(field string, description string) *ErrTodoInvalidArgumentRestorePoint {
+	err := (*liberrors.Error)(e)
+	return (*ErrTodoInvalidArgumentRestorePoint)(err.WithViolation(field, description))
+}
+
 // ErrTodoNotFoundTodo ...
 type ErrTodoNotFoundTodo liberrors.Error
 
@@ -76,7 +195,10 @@ func NewErrTodoNotFoundTodo() *ErrTodoNotFoundTodo {
 	return &ErrTodoNotFoundTodo{
 		RPCStatus: 5,
 		Code:      "0501",
-		Message:   "Not found todo",
+		Message:   "Not found todo {todoId}",
+		Messages: map[string]string{
+			"vi": "Không tìm thấy todo {todoId}",
+		},
 	}
 }
 
@@ -85,6 +207,36 @@ func (e *ErrTodoNotFoundTodo) Err() error {
 	return (*liberrors.Error)(e)
 }
 
+// Is ...
+func (e *ErrTodoNotFoundTodo) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrTodoNotFoundTodo) From(err error) (*ErrTodoNotFoundTodo, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrTodoNotFoundTodo)(domainErr), true
+}
+
+// WithTodoId ...
+func (e *ErrTodoNotFoundTodo) WithTodoId(value int64) *ErrTodoNotFoundTodo {
+	err := (*liberrors.Error)(e)
+	return (*ErrTodoNotFoundTodo)(err.WithDetail("todoId", value))
+}
+
+// GetTodoId ...
+func (e *ErrTodoNotFoundTodo) GetTodoId() int64 {
+	value, _ := e.Details["todoId"].(int64)
+	return value
+}
+
 // ErrTodoNotFoundTodoItem ...
 type ErrTodoNotFoundTodoItem liberrors.Error
 
@@ -94,6 +246,9 @@ func NewErrTodoNotFoundTodoItem() *ErrTodoNotFoundTodoItem {
 		RPCStatus: 5,
 		Code:      "0502",
 		Message:   "Not found todo item",
+		Messages: map[string]string{
+			"vi": "Không tìm thấy mục todo",
+		},
 	}
 }
 
@@ -102,16 +257,156 @@ func (e *ErrTodoNotFoundTodoItem) Err() error {
 	return (*liberrors.Error)(e)
 }
 
+// Is ...
+func (e *ErrTodoNotFoundTodoItem) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrTodoNotFoundTodoItem) From(err error) (*ErrTodoNotFoundTodoItem, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrTodoNotFoundTodoItem)(domainErr), true
+}
+
+// ErrTodoNotFoundTodoVersion ...
+type ErrTodoNotFoundTodoVersion liberrors.Error
+
+// NewErrTodoNotFoundTodoVersion ...
+func NewErrTodoNotFoundTodoVersion() *ErrTodoNotFoundTodoVersion {
+	return &ErrTodoNotFoundTodoVersion{
+		RPCStatus: 5,
+		Code:      "0503",
+		Message:   "Not found any version of the todo at the restore point",
+		Messages: map[string]string{
+			"vi": "Không tìm thấy phiên bản nào của todo tại thời điểm khôi phục",
+		},
+	}
+}
+
+// Err ...
+func (e *ErrTodoNotFoundTodoVersion) Err() error {
+	return (*liberrors.Error)(e)
+}
+
+// Is ...
+func (e *ErrTodoNotFoundTodoVersion) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrTodoNotFoundTodoVersion) From(err error) (*ErrTodoNotFoundTodoVersion, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrTodoNotFoundTodoVersion)(domainErr), true
+}
+
+// ErrTodoUnimplementedListTodos ...
+type ErrTodoUnimplementedListTodos liberrors.Error
+
+// NewErrTodoUnimplementedListTodos ...
+func NewErrTodoUnimplementedListTodos() *ErrTodoUnimplementedListTodos {
+	return &ErrTodoUnimplementedListTodos{
+		RPCStatus: 12,
+		Code:      "1200",
+		Message:   "Listing todos needs the todo summary projection",
+		Messages: map[string]string{
+			"vi": "Liệt kê todo cần bật projection tóm tắt todo",
+		},
+	}
+}
+
+// Err ...
+func (e *ErrTodoUnimplementedListTodos) Err() error {
+	return (*liberrors.Error)(e)
+}
+
+// Is ...
+func (e *ErrTodoUnimplementedListTodos) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrTodoUnimplementedListTodos) From(err error) (*ErrTodoUnimplementedListTodos, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrTodoUnimplementedListTodos)(domainErr), true
+}
+
+// ErrTodoUnimplementedTodoHistory ...
+type ErrTodoUnimplementedTodoHistory liberrors.Error
+
+// NewErrTodoUnimplementedTodoHistory ...
+func NewErrTodoUnimplementedTodoHistory() *ErrTodoUnimplementedTodoHistory {
+	return &ErrTodoUnimplementedTodoHistory{
+		RPCStatus: 12,
+		Code:      "1201",
+		Message:   "Todo history needs a SQL storage backend",
+		Messages: map[string]string{
+			"vi": "Lịch sử todo cần backend lưu trữ SQL",
+		},
+	}
+}
+
+// Err ...
+func (e *ErrTodoUnimplementedTodoHistory) Err() error {
+	return (*liberrors.Error)(e)
+}
+
+// Is ...
+func (e *ErrTodoUnimplementedTodoHistory) Is(err error) bool {
+	domainErr, ok := liberrors.FromError(err)
+	if !ok {
+		return false
+	}
+	return domainErr.Code == e.Code
+}
+
+// From ...
+func (e *ErrTodoUnimplementedTodoHistory) From(err error) (*ErrTodoUnimplementedTodoHistory, bool) {
+	if !e.Is(err) {
+		return nil, false
+	}
+	domainErr, _ := liberrors.FromError(err)
+	return (*ErrTodoUnimplementedTodoHistory)(domainErr), true
+}
+
 // TodoTag ...
 type TodoTag struct {
-	InvalidArgumentEmptyItems *ErrTodoInvalidArgumentEmptyItems
-	NotFoundTodo              *ErrTodoNotFoundTodo
-	NotFoundTodoItem          *ErrTodoNotFoundTodoItem
+	InvalidArgumentEmptyItems   *ErrTodoInvalidArgumentEmptyItems
+	InvalidArgumentRestorePoint *ErrTodoInvalidArgumentRestorePoint
+	NotFoundTodo                *ErrTodoNotFoundTodo
+	NotFoundTodoItem            *ErrTodoNotFoundTodoItem
+	NotFoundTodoVersion         *ErrTodoNotFoundTodoVersion
+	UnimplementedListTodos      *ErrTodoUnimplementedListTodos
+	UnimplementedTodoHistory    *ErrTodoUnimplementedTodoHistory
 }
 
 // Todo ...
 var Todo = &TodoTag{
-	InvalidArgumentEmptyItems: NewErrTodoInvalidArgumentEmptyItems(),
-	NotFoundTodo:              NewErrTodoNotFoundTodo(),
-	NotFoundTodoItem:          NewErrTodoNotFoundTodoItem(),
+	InvalidArgumentEmptyItems:   NewErrTodoInvalidArgumentEmptyItems(),
+	InvalidArgumentRestorePoint: NewErrTodoInvalidArgumentRestorePoint(),
+	NotFoundTodo:                NewErrTodoNotFoundTodo(),
+	NotFoundTodoItem:            NewErrTodoNotFoundTodoItem(),
+	NotFoundTodoVersion:         NewErrTodoNotFoundTodoVersion(),
+	UnimplementedListTodos:      NewErrTodoUnimplementedListTodos(),
+	UnimplementedTodoHistory:    NewErrTodoUnimplementedTodoHistory(),
 }
@@ -2,16 +2,14 @@ package errors
 
 import (
 	"context"
-	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
-	"go.uber.org/zap"
+	liberrors "todoapp/lib/errors"
 )
 
-// WrapDBError wraps database errors
-func WrapDBError(ctx context.Context, err error) error {
+// WrapDBError wraps database errors, the cause is logged by liberrors.UnaryServerInterceptor
+func WrapDBError(_ context.Context, err error) error {
 	if err != nil {
-		ctxzap.Extract(ctx).WithOptions(zap.AddCallerSkip(1)).
-			Error("Error accessing database", zap.Error(err))
-		return General.InternalErrorAccessingDatabase.Err()
+		domainErr := (*liberrors.Error)(General.InternalErrorAccessingDatabase)
+		return domainErr.WrapCaller(err, 1)
 	}
 	return nil
 }
@@ -14,10 +14,15 @@ import (
 	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
 	common_server "todoapp/common/server"
 	"todoapp/config"
+	"todoapp/lib/dblib"
 	"todoapp/lib/errors"
 	"todoapp/lib/log"
-	"todoapp/lib/mysql"
+	"todoapp/todoapp/client"
+	"todoapp/todoapp/event/notify"
+	"todoapp/todoapp/repo"
 	todoapp_server "todoapp/todoapp/server"
+	"todoapp/todoapp/service"
+	"todoapp/todoapp/types"
 )
 
 // Root struct for whole app
@@ -28,19 +33,50 @@ type Root struct {
 
 	health        *common_server.HealthServer
 	todoappServer *todoapp_server.Server
+	eventClient   *client.EventClient
 }
 
-// NewRoot initializes gRPC servers
-func NewRoot(conf config.Config) *Root {
+// NewRoot initializes gRPC servers, the API server signals channel instead of the event server when not nil
+func NewRoot(conf config.Config, channel *notify.Channel) *Root {
 	logger := log.NewLogger(conf.Log)
-	db := mysql.MustConnect(conf.MySQL)
+	db := repo.MustConnect(conf)
 
-	conn, err := grpc.Dial(conf.Event.GRPC.String(), grpc.WithInsecure())
-	if err != nil {
-		panic(err)
+	if repo.Dialect(conf) == dblib.DialectMySQL {
+		// the partition of an inserted event is computed from the stored count
+		err := repo.CheckPartitionCount(db, conf.Event.PartitionCount())
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	var eventClient *client.EventClient
+	var signaller types.EventClient = channel
+	if channel == nil {
+		if repo.Dialect(conf) == repo.DialectMemory {
+			panic("Memory storage needs the event core running in the API server")
+		}
+
+		conn, err := grpc.Dial(conf.Event.GRPC.String(),
+			grpc.WithInsecure(),
+			grpc.WithUnaryInterceptor(errors.UnaryClientInterceptor),
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		eventClient = client.NewEventClient(conn, logger)
+		signaller = eventClient
 	}
 
-	todoappServer := todoapp_server.InitServer(db, conn)
+	var options []service.Option
+	if conf.Server.ListFromProjection {
+		if repo.Dialect(conf) != dblib.DialectMySQL {
+			panic("Todo summary projection is only supported on mysql")
+		}
+		options = append(options, service.WithTodoSummaries(repo.NewProjectionRepository(db)))
+	}
+
+	todoappServer := todoapp_server.InitServer(db, signaller, options...)
 
 	return &Root{
 		conf:   conf,
@@ -49,6 +85,7 @@ func NewRoot(conf config.Config) *Root {
 
 		health:        &common_server.HealthServer{},
 		todoappServer: todoappServer,
+		eventClient:   eventClient,
 	}
 }
 
@@ -91,10 +128,19 @@ func (r *Root) Register(ctx context.Context, server *grpc.Server, mux *runtime.S
 	}
 }
 
+// Run runs background jobs until ctx is done
+func (r *Root) Run(ctx context.Context) {
+	if r.eventClient != nil {
+		r.eventClient.Run(ctx)
+	}
+}
+
 // Shutdown for graceful shutdown
 func (r *Root) Shutdown() {
-	if err := r.db.Close(); err != nil {
-		panic(err)
+	if r.db != nil {
+		if err := r.db.Close(); err != nil {
+			panic(err)
+		}
 	}
 
 	r.logger.Info("Graceful shutdown completed")
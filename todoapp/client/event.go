@@ -2,32 +2,103 @@ package client
 
 import (
 	"context"
-	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"time"
 	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
 	"todoapp/todoapp/types"
 )
 
-// EventClient ...
+// EventClient signals the event server in background.
+// Signals arrived while one is pending are coalesced into a single RPC
 type EventClient struct {
-	conn *grpc.ClientConn
+	client todoapp_rpc.EventServiceClient
+	logger *zap.Logger
+
+	signalChan chan struct{}
+
+	// options
+	timeout    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
 }
 
 var _ types.EventClient = &EventClient{}
 
 // NewEventClient ...
-func NewEventClient(conn *grpc.ClientConn) *EventClient {
+func NewEventClient(conn *grpc.ClientConn, logger *zap.Logger, options ...Option) *EventClient {
+	return newEventClient(todoapp_rpc.NewEventServiceClient(conn), logger, options...)
+}
+
+func newEventClient(client todoapp_rpc.EventServiceClient, logger *zap.Logger, options ...Option,
+) *EventClient {
+	opts := defaultEventClientOpts
+	applyOptions(&opts, options...)
+
 	return &EventClient{
-		conn: conn,
+		client: client,
+		logger: logger,
+
+		signalChan: make(chan struct{}, 1),
+
+		timeout:    opts.timeout,
+		minBackoff: opts.minBackoff,
+		maxBackoff: opts.maxBackoff,
+	}
+}
+
+// Signal never blocks, the RPC is made by Run
+func (c *EventClient) Signal(context.Context) {
+	select {
+	case c.signalChan <- struct{}{}:
+	default:
+		coalescedSignals.Inc()
+	}
+}
+
+func (c *EventClient) signal(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.client.Signal(ctx, &todoapp_rpc.SignalRequest{})
+	return err
+}
+
+func (c *EventClient) signalWithRetry(ctx context.Context) {
+	backoff := c.minBackoff
+	for {
+		err := c.signal(ctx)
+		if err == nil {
+			return
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		failedSignals.Inc()
+		c.logger.Error("client.Signal", zap.Error(err), zap.Duration("backoff", backoff))
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+
+		backoff *= 2
+		if backoff > c.maxBackoff {
+			backoff = c.maxBackoff
+		}
 	}
 }
 
-// Signal ...
-func (c *EventClient) Signal(ctx context.Context) {
-	client := todoapp_rpc.NewEventServiceClient(c.conn)
-	_, err := client.Signal(context.Background(), &todoapp_rpc.SignalRequest{})
-	if err != nil {
-		ctxzap.Extract(ctx).Error("client.Signal", zap.Error(err))
+// Run sends the signals until ctx is done
+func (c *EventClient) Run(ctx context.Context) {
+	for {
+		select {
+		case <-c.signalChan:
+			c.signalWithRetry(ctx)
+		case <-ctx.Done():
+			return
+		}
 	}
 }
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"sync/atomic"
+	"testing"
+	"time"
+	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
+)
+
+type fakeEventServiceClient struct {
+	calls    int32
+	failures int32
+	done     chan struct{}
+}
+
+func (c *fakeEventServiceClient) Signal(ctx context.Context, _ *todoapp_rpc.SignalRequest, _ ...grpc.CallOption,
+) (*todoapp_rpc.SignalResponse, error) {
+	n := atomic.AddInt32(&c.calls, 1)
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errors.New("missing deadline")
+	}
+	if n <= c.failures {
+		return nil, errors.New("event server is down")
+	}
+	c.done <- struct{}{}
+	return &todoapp_rpc.SignalResponse{}, nil
+}
+
+func TestEventClient_Signal_Coalesced(t *testing.T) {
+	fake := &fakeEventServiceClient{done: make(chan struct{}, 10)}
+	c := newEventClient(fake, zap.NewNop())
+
+	c.Signal(context.Background())
+	c.Signal(context.Background())
+	c.Signal(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(finished)
+	}()
+
+	<-fake.done
+	cancel()
+	<-finished
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fake.calls))
+}
+
+func TestEventClient_Signal_Retry(t *testing.T) {
+	fake := &fakeEventServiceClient{failures: 2, done: make(chan struct{}, 10)}
+	c := newEventClient(fake, zap.NewNop(), WithBackoff(time.Millisecond, 2*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	finished := make(chan struct{})
+	go func() {
+		c.Run(ctx)
+		close(finished)
+	}()
+
+	c.Signal(context.Background())
+
+	<-fake.done
+	cancel()
+	<-finished
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&fake.calls))
+}
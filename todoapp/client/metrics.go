@@ -0,0 +1,18 @@
+package client
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	coalescedSignals = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "todoapp_event_client_coalesced_signals_total",
+		Help: "Number of signals coalesced into an already pending signal",
+	})
+
+	failedSignals = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "todoapp_event_client_failed_signals_total",
+		Help: "Number of failed Signal RPCs, each is retried with backoff",
+	})
+)
@@ -0,0 +1,39 @@
+package client
+
+import "time"
+
+// Option ...
+type Option func(opts *eventClientOpts)
+
+type eventClientOpts struct {
+	timeout    time.Duration
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+var defaultEventClientOpts = eventClientOpts{
+	timeout:    3 * time.Second,
+	minBackoff: 100 * time.Millisecond,
+	maxBackoff: 10 * time.Second,
+}
+
+// WithTimeout the deadline of each Signal RPC
+func WithTimeout(d time.Duration) Option {
+	return func(opts *eventClientOpts) {
+		opts.timeout = d
+	}
+}
+
+// WithBackoff the retry backoff, doubled after each failure
+func WithBackoff(min time.Duration, max time.Duration) Option {
+	return func(opts *eventClientOpts) {
+		opts.minBackoff = min
+		opts.maxBackoff = max
+	}
+}
+
+func applyOptions(opts *eventClientOpts, options ...Option) {
+	for _, o := range options {
+		o(opts)
+	}
+}
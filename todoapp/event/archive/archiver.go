@@ -0,0 +1,183 @@
+package archive
+
+import (
+	"context"
+	"time"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+
+	"go.uber.org/zap"
+)
+
+// Repository ...
+type Repository interface {
+	GetFirstSequence(ctx context.Context) (uint64, error)
+	GetLogHead(ctx context.Context) (uint64, error)
+	GetLastSequenceCreatedBefore(ctx context.Context, before time.Time) (uint64, error)
+	GetPublisherSequence(ctx context.Context, id core.PublisherID) (uint64, error)
+
+	GetEventsInRange(ctx context.Context, from uint64, to uint64) ([]model.Event, error)
+	DeleteEventsInRange(ctx context.Context, from uint64, to uint64) error
+}
+
+// Archiver moves old or consumed events from todo_events to the archive store
+type Archiver struct {
+	repo   Repository
+	store  *Store
+	logger *zap.Logger
+
+	publishers []core.PublisherID
+
+	// options
+	interval  time.Duration
+	maxAge    time.Duration
+	batchSize uint64
+	keepLast  uint64
+	now       func() time.Time
+}
+
+// NewArchiver ...
+func NewArchiver(
+	repo Repository, store *Store, logger *zap.Logger,
+	publishers []core.PublisherID, options ...Option,
+) *Archiver {
+	opts := defaultArchiverOpts
+	applyOptions(&opts, options...)
+
+	if opts.keepLast == 0 {
+		opts.keepLast = 1
+	}
+	if opts.batchSize == 0 {
+		opts.batchSize = defaultArchiverOpts.batchSize
+	}
+
+	return &Archiver{
+		repo:   repo,
+		store:  store,
+		logger: logger,
+
+		publishers: publishers,
+
+		interval:  opts.interval,
+		maxAge:    opts.maxAge,
+		batchSize: opts.batchSize,
+		keepLast:  opts.keepLast,
+		now:       opts.now,
+	}
+}
+
+// computeArchiveBound returns the greatest sequence can be archived,
+// the last keepLast events are always kept, the core relies on them to continue the sequence
+func computeArchiveBound(head uint64, keepLast uint64, consumed uint64, aged uint64) uint64 {
+	bound := consumed
+	if aged > bound {
+		bound = aged
+	}
+
+	if head <= keepLast {
+		return 0
+	}
+	if bound > head-keepLast {
+		return head - keepLast
+	}
+	return bound
+}
+
+func (a *Archiver) getConsumedSequence(ctx context.Context) (uint64, error) {
+	if len(a.publishers) == 0 {
+		return 0, nil
+	}
+
+	var result uint64
+	for i, id := range a.publishers {
+		seq, err := a.repo.GetPublisherSequence(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		if i == 0 || seq < result {
+			result = seq
+		}
+	}
+	return result, nil
+}
+
+// ArchiveOnce archives all the events satisfied the retention policy, returns the number of archived events
+func (a *Archiver) ArchiveOnce(ctx context.Context) (uint64, error) {
+	first, err := a.repo.GetFirstSequence(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if first == 0 {
+		return 0, nil
+	}
+
+	head, err := a.repo.GetLogHead(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	consumed, err := a.getConsumedSequence(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	aged := uint64(0)
+	if a.maxAge > 0 {
+		aged, err = a.repo.GetLastSequenceCreatedBefore(ctx, a.now().Add(-a.maxAge))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	bound := computeArchiveBound(head, a.keepLast, consumed, aged)
+
+	count := uint64(0)
+	for from := first; from <= bound; {
+		to := from + a.batchSize - 1
+		if to > bound {
+			to = bound
+		}
+
+		events, err := a.repo.GetEventsInRange(ctx, from, to)
+		if err != nil {
+			return count, err
+		}
+
+		// the file MUST be written before the rows are deleted
+		err = a.store.Write(events)
+		if err != nil {
+			return count, err
+		}
+
+		err = a.repo.DeleteEventsInRange(ctx, from, to)
+		if err != nil {
+			return count, err
+		}
+
+		count += uint64(len(events))
+		archivedEvents.Add(float64(len(events)))
+
+		from = to + 1
+	}
+
+	return count, nil
+}
+
+// Run archives events periodically
+func (a *Archiver) Run(ctx context.Context) {
+	for {
+		count, err := a.ArchiveOnce(ctx)
+		if err != nil {
+			a.logger.Error("archiver.ArchiveOnce", zap.Error(err))
+		} else if count > 0 {
+			a.logger.Info("Archived events", zap.Uint64("count", count))
+		}
+
+		select {
+		case <-time.After(a.interval):
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
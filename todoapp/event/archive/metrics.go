@@ -0,0 +1,11 @@
+package archive
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var archivedEvents = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "todoapp_event_archived_total",
+	Help: "Number of events moved from todo_events to the archive",
+})
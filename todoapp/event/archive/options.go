@@ -0,0 +1,56 @@
+package archive
+
+import "time"
+
+// Option ...
+type Option func(opts *archiverOpts)
+
+type archiverOpts struct {
+	interval  time.Duration
+	maxAge    time.Duration
+	batchSize uint64
+	keepLast  uint64
+	now       func() time.Time
+}
+
+var defaultArchiverOpts = archiverOpts{
+	interval:  1 * time.Hour,
+	maxAge:    0,
+	batchSize: 10000,
+	keepLast:  1000,
+	now:       time.Now,
+}
+
+// WithInterval ...
+func WithInterval(d time.Duration) Option {
+	return func(opts *archiverOpts) {
+		opts.interval = d
+	}
+}
+
+// WithMaxAge events older than d are archived, even when not consumed by all publishers, zero for disabling
+func WithMaxAge(d time.Duration) Option {
+	return func(opts *archiverOpts) {
+		opts.maxAge = d
+	}
+}
+
+// WithBatchSize the maximum number of events in an archive file
+func WithBatchSize(size uint64) Option {
+	return func(opts *archiverOpts) {
+		opts.batchSize = size
+	}
+}
+
+// WithKeepLast the number of latest events always kept in todo_events
+func WithKeepLast(n uint64) Option {
+	return func(opts *archiverOpts) {
+		opts.keepLast = n
+	}
+}
+
+func applyOptions(opts *archiverOpts, options ...Option) {
+	for _, o := range options {
+		o(opts)
+	}
+}
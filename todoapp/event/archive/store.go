@@ -0,0 +1,253 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"todoapp/todoapp/model"
+)
+
+const (
+	fileNameFormat = "todo_events_%020d_%020d" + fileExtension
+	fileExtension  = ".jsonl.gz"
+)
+
+type record struct {
+	ID        uint64    `json:"id"`
+	Sequence  uint64    `json:"sequence"`
+	Data      []byte    `json:"data"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type fileRange struct {
+	from uint64
+	to   uint64
+	name string
+}
+
+// Store keeps archived events in gzip compressed files in a local directory,
+// each file contains a contiguous range of sequences
+type Store struct {
+	dir string
+
+	mut   sync.Mutex
+	files []fileRange
+}
+
+// NewStore ...
+func NewStore(dir string) (*Store, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Store{
+		dir: dir,
+	}
+
+	err = s.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Store) loadIndex() error {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+
+	files := make([]fileRange, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), fileExtension) {
+			continue
+		}
+
+		var f fileRange
+		_, err := fmt.Sscanf(entry.Name(), fileNameFormat, &f.from, &f.to)
+		if err != nil {
+			continue
+		}
+		f.name = entry.Name()
+		files = append(files, f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].from < files[j].from
+	})
+
+	s.mut.Lock()
+	s.files = files
+	s.mut.Unlock()
+
+	return nil
+}
+
+// Write archives events, events must be ordered by sequence
+func (s *Store) Write(events []model.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	from := uint64(events[0].Sequence.Int64)
+	to := uint64(events[len(events)-1].Sequence.Int64)
+	name := fmt.Sprintf(fileNameFormat, from, to)
+
+	tmp, err := ioutil.TempFile(s.dir, name+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	err = writeEvents(tmp, events)
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = tmp.Sync()
+	if err != nil {
+		_ = tmp.Close()
+		return err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	err = os.Rename(tmp.Name(), filepath.Join(s.dir, name))
+	if err != nil {
+		return err
+	}
+
+	return s.loadIndex()
+}
+
+// LastSequence returns the greatest archived sequence, 0 when nothing is archived
+func (s *Store) LastSequence() (uint64, error) {
+	err := s.loadIndex()
+	if err != nil {
+		return 0, err
+	}
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if len(s.files) == 0 {
+		return 0, nil
+	}
+	return s.files[len(s.files)-1].to, nil
+}
+
+func writeEvents(w io.Writer, events []model.Event) error {
+	zw := gzip.NewWriter(w)
+	encoder := json.NewEncoder(zw)
+	for _, e := range events {
+		err := encoder.Encode(record{
+			ID:        uint64(e.ID),
+			Sequence:  uint64(e.Sequence.Int64),
+			Data:      []byte(e.Data),
+			CreatedAt: e.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func readEvents(r io.Reader, from uint64, limit uint64, result []model.Event) ([]model.Event, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := bufio.NewScanner(zr)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() && uint64(len(result)) < limit {
+		var rec record
+		err := json.Unmarshal(scanner.Bytes(), &rec)
+		if err != nil {
+			return nil, err
+		}
+		if rec.Sequence < from {
+			continue
+		}
+
+		result = append(result, model.Event{
+			ID: model.EventID(rec.ID),
+			Sequence: sql.NullInt64{
+				Valid: true,
+				Int64: int64(rec.Sequence),
+			},
+			Data:      string(rec.Data),
+			CreatedAt: rec.CreatedAt,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return result, zr.Close()
+}
+
+func (s *Store) findFiles(from uint64) []fileRange {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	var result []fileRange
+	next := from
+	for _, f := range s.files {
+		if f.from <= next && next <= f.to {
+			result = append(result, f)
+			next = f.to + 1
+		}
+	}
+	return result
+}
+
+// Read returns at most limit archived events, starting from sequence from
+func (s *Store) Read(from uint64, limit uint64) ([]model.Event, error) {
+	files := s.findFiles(from)
+	if len(files) == 0 {
+		err := s.loadIndex()
+		if err != nil {
+			return nil, err
+		}
+		files = s.findFiles(from)
+	}
+
+	var result []model.Event
+	for _, f := range files {
+		if uint64(len(result)) >= limit {
+			break
+		}
+
+		file, err := os.Open(filepath.Join(s.dir, f.name))
+		if err != nil {
+			return nil, err
+		}
+
+		next := from
+		if len(result) > 0 {
+			next = uint64(result[len(result)-1].Sequence.Int64) + 1
+		}
+
+		result, err = readEvents(file, next, limit, result)
+		_ = file.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
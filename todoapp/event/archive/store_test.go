@@ -0,0 +1,109 @@
+package archive
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+	"todoapp/todoapp/model"
+)
+
+func newEvents(from uint64, to uint64) []model.Event {
+	var result []model.Event
+	for seq := from; seq <= to; seq++ {
+		result = append(result, model.Event{
+			ID:        model.EventID(seq + 100),
+			Sequence:  sql.NullInt64{Valid: true, Int64: int64(seq)},
+			Data:      "event data",
+			CreatedAt: time.Date(2020, 12, 20, 10, 0, int(seq), 0, time.UTC),
+		})
+	}
+	return result
+}
+
+func TestStore_WriteRead(t *testing.T) {
+	dir, err := ioutil.TempDir("", "archive")
+	assert.Nil(t, err)
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	s, err := NewStore(dir)
+	assert.Nil(t, err)
+
+	assert.Nil(t, s.Write(newEvents(1, 10)))
+	assert.Nil(t, s.Write(newEvents(11, 20)))
+
+	last, err := s.LastSequence()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(20), last)
+
+	events, err := s.Read(5, 3)
+	assert.Nil(t, err)
+	assert.Equal(t, newEvents(5, 7), events)
+
+	events, err = s.Read(8, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, newEvents(8, 12), events)
+
+	events, err = s.Read(21, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(events))
+
+	// reload from disk
+	s, err = NewStore(dir)
+	assert.Nil(t, err)
+
+	events, err = s.Read(18, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, newEvents(18, 20), events)
+}
+
+func TestComputeArchiveBound(t *testing.T) {
+	table := []struct {
+		name     string
+		head     uint64
+		keepLast uint64
+		consumed uint64
+		aged     uint64
+		expected uint64
+	}{
+		{
+			name:     "consumed",
+			head:     100,
+			keepLast: 10,
+			consumed: 50,
+			aged:     20,
+			expected: 50,
+		},
+		{
+			name:     "aged",
+			head:     100,
+			keepLast: 10,
+			consumed: 20,
+			aged:     60,
+			expected: 60,
+		},
+		{
+			name:     "keep-last",
+			head:     100,
+			keepLast: 10,
+			consumed: 100,
+			expected: 90,
+		},
+		{
+			name:     "head-smaller-than-keep-last",
+			head:     5,
+			keepLast: 10,
+			consumed: 5,
+			expected: 0,
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			result := computeArchiveBound(e.head, e.keepLast, e.consumed, e.aged)
+			assert.Equal(t, e.expected, result)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package core_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/memory"
+	"todoapp/todoapp/service"
+	"todoapp/todoapp/types"
+)
+
+type nopEventClient struct {
+}
+
+func (nopEventClient) Signal(context.Context) {
+}
+
+type blockingPublisher struct {
+	started chan []core.Event
+	release chan struct{}
+
+	mut   sync.Mutex
+	calls int
+}
+
+func (p *blockingPublisher) GetID() core.PublisherID {
+	return 1
+}
+
+func (p *blockingPublisher) Publish(events []core.Event) error {
+	p.mut.Lock()
+	p.calls++
+	p.mut.Unlock()
+
+	p.started <- events
+	<-p.release
+	return nil
+}
+
+func (p *blockingPublisher) getCalls() int {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return p.calls
+}
+
+type failingSaveRepo struct {
+	core.Repository
+}
+
+func (r failingSaveRepo) SaveLastSequence(core.PublisherID, uint64) error {
+	return errors.New("save error")
+}
+
+// runDrainCore cancels Run while the first batch is being published,
+// finish releases the publisher when release is true and waits for Run to return
+func runDrainCore(t *testing.T, repo core.Repository, store *memory.Store, drainTimeout time.Duration,
+) (*core.Core, *blockingPublisher, []core.Event, func(release bool)) {
+	publisher := &blockingPublisher{
+		started: make(chan []core.Event, 1),
+		release: make(chan struct{}),
+	}
+	eventCore := core.NewCore(repo,
+		core.WithErrorTimeout(time.Second),
+		core.WithDrainTimeout(drainTimeout),
+		core.AddPublisher(publisher),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		eventCore.Run(ctx)
+		close(done)
+	}()
+
+	s := service.NewService(memory.NewRepository(store), nopEventClient{})
+	for i := 0; i < 3; i++ {
+		_, err := s.SaveTodo(ctx, types.SaveTodoInput{Name: "some todo"})
+		assert.Nil(t, err)
+	}
+	eventCore.Signal()
+
+	batch := <-publisher.started
+	cancel()
+
+	return eventCore, publisher, batch, func(release bool) {
+		if release {
+			close(publisher.release)
+		}
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for Run")
+		}
+	}
+}
+
+func TestCore_Drain_FinishesBatch(t *testing.T) {
+	store := memory.NewStore()
+	repo := memory.NewEventRepository(store)
+
+	eventCore, publisher, batch, finish := runDrainCore(t, repo, store, 5*time.Second)
+	finish(true)
+
+	// the batch being published is checkpointed, no new batch is started
+	last := batch[len(batch)-1].Sequence
+	seq, err := repo.GetLastSequence(1)
+	assert.Nil(t, err)
+	assert.Equal(t, last, seq)
+	assert.Equal(t, 1, publisher.getCalls())
+	assert.Equal(t, map[core.PublisherID]uint64{1: 3 - last}, eventCore.Pending())
+}
+
+func TestCore_Drain_Timeout(t *testing.T) {
+	store := memory.NewStore()
+	repo := failingSaveRepo{Repository: memory.NewEventRepository(store)}
+
+	eventCore, _, _, finish := runDrainCore(t, repo, store, 100*time.Millisecond)
+	finish(true)
+
+	assert.Equal(t, map[core.PublisherID]uint64{1: 3}, eventCore.Pending())
+}
+
+func TestCore_Drain_PublishNotReturning(t *testing.T) {
+	store := memory.NewStore()
+	repo := memory.NewEventRepository(store)
+
+	eventCore, publisher, _, finish := runDrainCore(t, repo, store, 100*time.Millisecond)
+	defer close(publisher.release)
+
+	// Run returns after the drain timeout even though Publish never returns
+	finish(false)
+
+	seq, err := repo.GetLastSequence(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), seq)
+	assert.Equal(t, map[core.PublisherID]uint64{1: 3}, eventCore.Pending())
+}
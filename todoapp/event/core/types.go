@@ -1,17 +1,22 @@
 package core
 
-import "todoapp/todoapp/types"
+import (
+	"todoapp/lib/outbox"
+	"todoapp/todoapp/types"
+)
 
 // Event ...
 type Event types.Event
 
-// SetSequenceImpl ...
-func SetSequenceImpl(e Event, seq uint64) Event {
-	e.Sequence = seq
-	return e
-}
+var _ outbox.Event = Event{}
 
-// GetSequenceImpl ...
-func GetSequenceImpl(e Event) uint64 {
+// GetSequence ...
+func (e Event) GetSequence() uint64 {
 	return e.Sequence
 }
+
+// WithSequence ...
+func (e Event) WithSequence(seq uint64) outbox.Event {
+	e.Sequence = seq
+	return e
+}
@@ -0,0 +1,113 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"todoapp/lib/mysql"
+	"todoapp/todoapp/event/core"
+
+	"github.com/siddontang/go-mysql/client"
+	gomysql "github.com/siddontang/go-mysql/mysql"
+	"github.com/siddontang/go-mysql/replication"
+)
+
+// Binlog reads the MySQL binlog as a replica and signals on every insert into the events table.
+// The MySQL server MUST enable binlog_format = ROW, the user needs REPLICATION SLAVE and REPLICATION CLIENT
+type Binlog struct {
+	conf     mysql.Config
+	serverID uint32
+	table    string
+}
+
+var _ core.Notifier = &Binlog{}
+
+// NewBinlog serverID MUST be unique among replicas of the MySQL server
+func NewBinlog(conf mysql.Config, serverID uint32, table string) *Binlog {
+	return &Binlog{
+		conf:     conf,
+		serverID: serverID,
+		table:    table,
+	}
+}
+
+func (b *Binlog) getMasterPosition() (gomysql.Position, error) {
+	addr := fmt.Sprintf("%s:%d", b.conf.Host, b.conf.Port)
+	conn, err := client.Connect(addr, b.conf.Username, b.conf.Password, "")
+	if err != nil {
+		return gomysql.Position{}, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	res, err := conn.Execute("SHOW MASTER STATUS")
+	if err != nil {
+		return gomysql.Position{}, err
+	}
+	if res.RowNumber() == 0 {
+		return gomysql.Position{}, fmt.Errorf("binlog is not enabled")
+	}
+
+	name, err := res.GetString(0, 0)
+	if err != nil {
+		return gomysql.Position{}, err
+	}
+	pos, err := res.GetUint(0, 1)
+	if err != nil {
+		return gomysql.Position{}, err
+	}
+
+	return gomysql.Position{Name: name, Pos: uint32(pos)}, nil
+}
+
+func isWriteRowsEvent(t replication.EventType) bool {
+	return t == replication.WRITE_ROWS_EVENTv0 ||
+		t == replication.WRITE_ROWS_EVENTv1 ||
+		t == replication.WRITE_ROWS_EVENTv2
+}
+
+// Run streams the binlog from the current position, events inserted while not running
+// are picked up by the first signal and the DB processor's periodic poll
+func (b *Binlog) Run(ctx context.Context, signal func()) error {
+	pos, err := b.getMasterPosition()
+	if err != nil {
+		return err
+	}
+
+	syncer := replication.NewBinlogSyncer(replication.BinlogSyncerConfig{
+		ServerID: b.serverID,
+		Flavor:   "mysql",
+		Host:     b.conf.Host,
+		Port:     b.conf.Port,
+		User:     b.conf.Username,
+		Password: b.conf.Password,
+	})
+	defer syncer.Close()
+
+	streamer, err := syncer.StartSync(pos)
+	if err != nil {
+		return err
+	}
+
+	signal()
+
+	for {
+		ev, err := streamer.GetEvent(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if !isWriteRowsEvent(ev.Header.EventType) {
+			continue
+		}
+
+		rows, ok := ev.Event.(*replication.RowsEvent)
+		if !ok {
+			continue
+		}
+		if string(rows.Table.Schema) == b.conf.Database && string(rows.Table.Table) == b.table {
+			signal()
+		}
+	}
+}
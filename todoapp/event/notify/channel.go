@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/types"
+)
+
+// Channel is an in-process change notification source,
+// for deployments running the API server and the event core in a single binary
+type Channel struct {
+	ch chan struct{}
+}
+
+var _ core.Notifier = &Channel{}
+var _ types.EventClient = &Channel{}
+
+// NewChannel ...
+func NewChannel() *Channel {
+	return &Channel{
+		ch: make(chan struct{}, 1),
+	}
+}
+
+// Signal is used in place of the gRPC EventClient, never blocks
+func (c *Channel) Signal(context.Context) {
+	select {
+	case c.ch <- struct{}{}:
+	default:
+	}
+}
+
+// Run ...
+func (c *Channel) Run(ctx context.Context, signal func()) error {
+	for {
+		select {
+		case <-c.ch:
+			signal()
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
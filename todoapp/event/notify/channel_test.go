@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestChannel_Signal_NotBlocking(t *testing.T) {
+	c := NewChannel()
+	c.Signal(context.Background())
+	c.Signal(context.Background())
+	c.Signal(context.Background())
+
+	assert.Equal(t, 1, len(c.ch))
+}
+
+func TestChannel_Run(t *testing.T) {
+	c := NewChannel()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	signaled := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- c.Run(ctx, func() {
+			signaled <- struct{}{}
+		})
+	}()
+
+	c.Signal(context.Background())
+	<-signaled
+
+	cancel()
+	assert.Nil(t, <-done)
+}
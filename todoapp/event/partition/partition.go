@@ -0,0 +1,67 @@
+package partition
+
+import (
+	"context"
+	"sync"
+	"todoapp/todoapp/event/core"
+)
+
+// NewRepository creates the core.Repository of a partition
+type NewRepository func(partition uint32) core.Repository
+
+// Group runs one core.Core per partition of the todo events,
+// the publishers of different partitions run in parallel, events of a todo are always in the same partition
+type Group struct {
+	cores []*core.Core
+}
+
+// NewGroup creates count cores sharing the options, the publishers are called concurrently by the cores
+func NewGroup(count uint32, newRepo NewRepository, options ...core.Option) *Group {
+	if count == 0 {
+		panic("count must not be zero")
+	}
+
+	cores := make([]*core.Core, 0, count)
+	for p := uint32(0); p < count; p++ {
+		cores = append(cores, core.NewCore(newRepo(p), options...))
+	}
+	return &Group{
+		cores: cores,
+	}
+}
+
+// Run runs the cores until ctx is done
+func (g *Group) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(len(g.cores))
+
+	for _, c := range g.cores {
+		eventCore := c
+
+		go func() {
+			defer wg.Done()
+
+			eventCore.Run(ctx)
+		}()
+	}
+
+	wg.Wait()
+}
+
+// Pending returns for each publisher the number of sequenced events it has not published yet in all partitions
+func (g *Group) Pending() map[core.PublisherID]uint64 {
+	result := make(map[core.PublisherID]uint64)
+	for _, c := range g.cores {
+		for id, count := range c.Pending() {
+			result[id] += count
+		}
+	}
+	return result
+}
+
+// Signal signals all the cores
+func (g *Group) Signal() {
+	for _, c := range g.cores {
+		c.Signal()
+	}
+}
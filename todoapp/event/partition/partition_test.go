@@ -0,0 +1,80 @@
+package partition_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"sort"
+	"testing"
+	"time"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/event/partition"
+	"todoapp/todoapp/memory"
+	"todoapp/todoapp/service"
+	"todoapp/todoapp/types"
+)
+
+type nopEventClient struct {
+}
+
+func (nopEventClient) Signal(context.Context) {
+}
+
+type collectPublisher struct {
+	events chan []core.Event
+}
+
+func (p *collectPublisher) GetID() core.PublisherID {
+	return 1
+}
+
+func (p *collectPublisher) Publish(events []core.Event) error {
+	// the core reuses the slice of events
+	p.events <- append([]core.Event(nil), events...)
+	return nil
+}
+
+func TestGroup(t *testing.T) {
+	stores := []*memory.Store{memory.NewStore(), memory.NewStore()}
+	publisher := &collectPublisher{events: make(chan []core.Event, 10)}
+
+	group := partition.NewGroup(2, func(p uint32) core.Repository {
+		return memory.NewEventRepository(stores[p])
+	},
+		core.WithErrorTimeout(time.Second),
+		core.AddPublisher(publisher),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go group.Run(ctx)
+
+	for i, store := range stores {
+		s := service.NewService(memory.NewRepository(store), nopEventClient{})
+		for j := 0; j <= i; j++ {
+			_, err := s.SaveTodo(ctx, types.SaveTodoInput{Name: "some todo"})
+			assert.Nil(t, err)
+		}
+	}
+	group.Signal()
+
+	var sequences []uint64
+	for len(sequences) < 3 {
+		select {
+		case events := <-publisher.events:
+			for _, e := range events {
+				sequences = append(sequences, e.Sequence)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for events")
+		}
+	}
+
+	sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+	assert.Equal(t, []uint64{1, 1, 2}, sequences)
+
+	select {
+	case events := <-publisher.events:
+		t.Fatal("unexpected events", events)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
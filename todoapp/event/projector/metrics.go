@@ -0,0 +1,11 @@
+package projector
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var projectedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "todoapp_projector_events_total",
+	Help: "Number of events received by projectors, including the skipped ones",
+}, []string{"projection"})
@@ -0,0 +1,166 @@
+//go:generate mockgen -destination=../../mocks/projector_repository.go -package=types_mocks -mock_names=Repository=MockProjectorRepository,TxnRepository=MockProjectorTxnRepository . Repository,TxnRepository
+
+package projector
+
+import (
+	"context"
+	"fmt"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+)
+
+type (
+	// Repository ...
+	Repository interface {
+		Transact(ctx context.Context, fn func(tx TxnRepository) error) error
+	}
+
+	// TxnRepository updates the read tables and the checkpoints in a transaction
+	TxnRepository interface {
+		// For Checkpoints, GetCheckpoint locks the checkpoint until the end of the transaction
+		GetCheckpoint(ctx context.Context, name string) (uint64, error)
+		SaveCheckpoint(ctx context.Context, name string, seq uint64) error
+
+		// For Todo Summaries
+		UpsertTodoSummary(ctx context.Context, summary model.TodoSummary) error
+		DeleteAllTodoSummaries(ctx context.Context) error
+	}
+
+	// Projection maintains read tables from events
+	Projection interface {
+		// Name identifies the checkpoint of the projection
+		Name() string
+		Apply(ctx context.Context, tx TxnRepository, event core.Event) error
+		// Reset deletes everything in the read tables
+		Reset(ctx context.Context, tx TxnRepository) error
+	}
+)
+
+// Projector a core.Publisher applying events to a projection.
+// Events and the checkpoint are saved in the same transaction,
+// events at or before the checkpoint are skipped, so each event is applied exactly once
+type Projector struct {
+	id         core.PublisherID
+	repo       Repository
+	projection Projection
+}
+
+var _ core.Publisher = &Projector{}
+
+// NewProjector ...
+func NewProjector(id core.PublisherID, repo Repository, projection Projection) *Projector {
+	return &Projector{
+		id:         id,
+		repo:       repo,
+		projection: projection,
+	}
+}
+
+// GetID ...
+func (p *Projector) GetID() core.PublisherID {
+	return p.id
+}
+
+// Publish ...
+func (p *Projector) Publish(events []core.Event) error {
+	_, err := p.apply(context.Background(), events)
+	return err
+}
+
+// apply returns the checkpoint after applying events
+func (p *Projector) apply(ctx context.Context, events []core.Event) (uint64, error) {
+	name := p.projection.Name()
+
+	var checkpoint uint64
+	err := p.repo.Transact(ctx, func(tx TxnRepository) error {
+		var err error
+		checkpoint, err = tx.GetCheckpoint(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		last, err := p.applyTx(ctx, tx, events, checkpoint)
+		if err != nil {
+			return err
+		}
+
+		if last == checkpoint {
+			return nil
+		}
+		checkpoint = last
+		return tx.SaveCheckpoint(ctx, name, checkpoint)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	projectedEvents.WithLabelValues(name).Add(float64(len(events)))
+	return checkpoint, nil
+}
+
+// applyTx applies events after the checkpoint, returns the sequence of the last applied event.
+// Returns an error when the events after the checkpoint do not start right after it,
+// like the events of a running Projector while Rebuild has not reached them yet
+func (p *Projector) applyTx(ctx context.Context, tx TxnRepository, events []core.Event, checkpoint uint64) (uint64, error) {
+	last := checkpoint
+	for _, e := range events {
+		if e.Sequence <= last {
+			continue
+		}
+		if last == checkpoint && e.Sequence != checkpoint+1 {
+			return 0, fmt.Errorf("projector: events of %s start from %d, the checkpoint is %d",
+				p.projection.Name(), e.Sequence, checkpoint)
+		}
+		err := p.projection.Apply(ctx, tx, e)
+		if err != nil {
+			return 0, err
+		}
+		last = e.Sequence
+	}
+	return last, nil
+}
+
+// Rebuild resets the projection then applies all events from the first sequence, a transaction per batch,
+// returns the checkpoint saved by the last committed batch.
+// A Projector running concurrently fails on the events after the checkpoint until the rebuild reaches them,
+// the projection is partial until the rebuild finishes, an interrupted rebuild MUST be run again
+func (p *Projector) Rebuild(ctx context.Context, events core.Repository, batchSize uint64) (uint64, error) {
+	name := p.projection.Name()
+
+	err := p.repo.Transact(ctx, func(tx TxnRepository) error {
+		_, err := tx.GetCheckpoint(ctx, name)
+		if err != nil {
+			return err
+		}
+
+		err = p.projection.Reset(ctx, tx)
+		if err != nil {
+			return err
+		}
+		return tx.SaveCheckpoint(ctx, name, 0)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	checkpoint := uint64(0)
+	for {
+		batch, err := events.GetEventsFromSequence(ctx, checkpoint+1, batchSize)
+		if err != nil {
+			return checkpoint, err
+		}
+		if len(batch) == 0 {
+			return checkpoint, nil
+		}
+
+		last, err := p.apply(ctx, batch)
+		if err != nil {
+			return checkpoint, err
+		}
+		checkpoint = last
+
+		if err := ctx.Err(); err != nil {
+			return checkpoint, err
+		}
+	}
+}
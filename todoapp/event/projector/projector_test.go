@@ -0,0 +1,224 @@
+package projector_test
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/event/projector"
+	types_mocks "todoapp/todoapp/mocks"
+	"todoapp/todoapp/model"
+)
+
+func todoSaveEvent(seq uint64, id uint64, name string, createdAt time.Time, items ...string) core.Event {
+	var eventItems []*todoapp_rpc.EventTodoItem
+	for i, item := range items {
+		eventItems = append(eventItems, &todoapp_rpc.EventTodoItem{
+			Id:   uint64(i + 1),
+			Name: item,
+		})
+	}
+
+	return core.Event{
+		ID:       model.EventID(seq + 100),
+		Sequence: seq,
+		Data: &todoapp_rpc.Event{
+			Type: todoapp_rpc.EventType_EVENT_TYPE_TODO_SAVE,
+			TodoSave: &todoapp_rpc.EventTodoSave{
+				Id:    id,
+				Name:  name,
+				Items: eventItems,
+			},
+		},
+		CreatedAt: createdAt,
+	}
+}
+
+func expectTransact(repo *types_mocks.MockProjectorRepository, tx projector.TxnRepository) {
+	repo.EXPECT().Transact(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(tx projector.TxnRepository) error) error {
+			return fn(tx)
+		})
+}
+
+func TestProjector_Publish_SkipsAppliedEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockProjectorRepository(ctrl)
+	tx := types_mocks.NewMockProjectorTxnRepository(ctrl)
+	p := projector.NewProjector(2, repo, projector.TodoSummaryProjection{})
+
+	now := time.Now()
+	expectTransact(repo, tx)
+	tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(11), nil)
+	tx.EXPECT().UpsertTodoSummary(gomock.Any(), model.TodoSummary{
+		ID:           5,
+		Name:         "new todo",
+		ItemCount:    3,
+		LastSequence: 12,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}).Return(nil)
+	tx.EXPECT().SaveCheckpoint(gomock.Any(), "todo_summary", uint64(12)).Return(nil)
+
+	err := p.Publish([]core.Event{
+		todoSaveEvent(10, 5, "old todo", now),
+		todoSaveEvent(11, 5, "old todo", now),
+		todoSaveEvent(12, 5, "new todo", now, "item 1", "item 2", "item 3"),
+	})
+	assert.Nil(t, err)
+}
+
+func TestProjector_Publish_AllApplied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockProjectorRepository(ctrl)
+	tx := types_mocks.NewMockProjectorTxnRepository(ctrl)
+	p := projector.NewProjector(2, repo, projector.TodoSummaryProjection{})
+
+	expectTransact(repo, tx)
+	tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(12), nil)
+
+	err := p.Publish([]core.Event{
+		todoSaveEvent(12, 5, "some todo", time.Now()),
+	})
+	assert.Nil(t, err)
+}
+
+func TestProjector_Publish_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockProjectorRepository(ctrl)
+	tx := types_mocks.NewMockProjectorTxnRepository(ctrl)
+	p := projector.NewProjector(2, repo, projector.TodoSummaryProjection{})
+
+	expectTransact(repo, tx)
+	tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(0), nil)
+	tx.EXPECT().UpsertTodoSummary(gomock.Any(), gomock.Any()).Return(errors.New("some error"))
+
+	err := p.Publish([]core.Event{
+		todoSaveEvent(1, 5, "some todo", time.Now()),
+	})
+	assert.Equal(t, errors.New("some error"), err)
+}
+
+type eventRepository struct {
+	core.Repository
+	events []core.Event
+}
+
+func (r *eventRepository) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]core.Event, error) {
+	var result []core.Event
+	for _, e := range r.events {
+		if e.Sequence >= seq && uint64(len(result)) < limit {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
+func TestProjector_Publish_Gap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockProjectorRepository(ctrl)
+	tx := types_mocks.NewMockProjectorTxnRepository(ctrl)
+	p := projector.NewProjector(2, repo, projector.TodoSummaryProjection{})
+
+	// a rebuild has only reached sequence 5
+	expectTransact(repo, tx)
+	tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(5), nil)
+
+	err := p.Publish([]core.Event{
+		todoSaveEvent(11, 5, "some todo", time.Now()),
+	})
+	assert.Equal(t, errors.New("projector: events of todo_summary start from 11, the checkpoint is 5"), err)
+}
+
+func TestProjector_Rebuild(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockProjectorRepository(ctrl)
+	tx := types_mocks.NewMockProjectorTxnRepository(ctrl)
+	p := projector.NewProjector(2, repo, projector.TodoSummaryProjection{})
+
+	now := time.Now()
+	events := &eventRepository{
+		events: []core.Event{
+			todoSaveEvent(1, 5, "todo 5", now),
+			todoSaveEvent(2, 6, "todo 6", now),
+			todoSaveEvent(3, 5, "new todo 5", now),
+		},
+	}
+
+	// the reset and each batch are committed in their own transaction
+	repo.EXPECT().Transact(gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(ctx context.Context, fn func(tx projector.TxnRepository) error) error {
+			return fn(tx)
+		})
+	gomock.InOrder(
+		tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(3), nil),
+		tx.EXPECT().DeleteAllTodoSummaries(gomock.Any()).Return(nil),
+		tx.EXPECT().SaveCheckpoint(gomock.Any(), "todo_summary", uint64(0)).Return(nil),
+
+		tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(0), nil),
+		tx.EXPECT().UpsertTodoSummary(gomock.Any(), gomock.Any()).Times(2).Return(nil),
+		tx.EXPECT().SaveCheckpoint(gomock.Any(), "todo_summary", uint64(2)).Return(nil),
+
+		tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(2), nil),
+		tx.EXPECT().UpsertTodoSummary(gomock.Any(), gomock.Any()).Return(nil),
+		tx.EXPECT().SaveCheckpoint(gomock.Any(), "todo_summary", uint64(3)).Return(nil),
+	)
+
+	checkpoint, err := p.Rebuild(context.Background(), events, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), checkpoint)
+}
+
+func TestProjector_Rebuild_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockProjectorRepository(ctrl)
+	tx := types_mocks.NewMockProjectorTxnRepository(ctrl)
+	p := projector.NewProjector(2, repo, projector.TodoSummaryProjection{})
+
+	now := time.Now()
+	events := &eventRepository{
+		events: []core.Event{
+			todoSaveEvent(1, 5, "todo 5", now),
+			todoSaveEvent(2, 6, "todo 6", now),
+			todoSaveEvent(3, 5, "new todo 5", now),
+		},
+	}
+
+	// the first batch stays committed
+	repo.EXPECT().Transact(gomock.Any(), gomock.Any()).Times(3).
+		DoAndReturn(func(ctx context.Context, fn func(tx projector.TxnRepository) error) error {
+			return fn(tx)
+		})
+	gomock.InOrder(
+		tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(3), nil),
+		tx.EXPECT().DeleteAllTodoSummaries(gomock.Any()).Return(nil),
+		tx.EXPECT().SaveCheckpoint(gomock.Any(), "todo_summary", uint64(0)).Return(nil),
+
+		tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(0), nil),
+		tx.EXPECT().UpsertTodoSummary(gomock.Any(), gomock.Any()).Times(2).Return(nil),
+		tx.EXPECT().SaveCheckpoint(gomock.Any(), "todo_summary", uint64(2)).Return(nil),
+
+		tx.EXPECT().GetCheckpoint(gomock.Any(), "todo_summary").Return(uint64(2), nil),
+		tx.EXPECT().UpsertTodoSummary(gomock.Any(), gomock.Any()).Return(errors.New("some error")),
+	)
+
+	checkpoint, err := p.Rebuild(context.Background(), events, 2)
+	assert.Equal(t, errors.New("some error"), err)
+	assert.Equal(t, uint64(2), checkpoint)
+}
@@ -0,0 +1,42 @@
+package projector
+
+import (
+	"context"
+	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+)
+
+// TodoSummaryProjection maintains todo summaries.
+// Item counts are taken from the items carried by the save events,
+// events saved before they carried items count no items
+type TodoSummaryProjection struct {
+}
+
+var _ Projection = TodoSummaryProjection{}
+
+// Name ...
+func (TodoSummaryProjection) Name() string {
+	return "todo_summary"
+}
+
+// Apply ...
+func (TodoSummaryProjection) Apply(ctx context.Context, tx TxnRepository, event core.Event) error {
+	if event.Data.Type != todoapp_rpc.EventType_EVENT_TYPE_TODO_SAVE {
+		return nil
+	}
+
+	return tx.UpsertTodoSummary(ctx, model.TodoSummary{
+		ID:           model.TodoID(event.Data.TodoSave.Id),
+		Name:         event.Data.TodoSave.Name,
+		ItemCount:    uint32(len(event.Data.TodoSave.Items)),
+		LastSequence: event.Sequence,
+		CreatedAt:    event.CreatedAt,
+		UpdatedAt:    event.CreatedAt,
+	})
+}
+
+// Reset ...
+func (TodoSummaryProjection) Reset(ctx context.Context, tx TxnRepository) error {
+	return tx.DeleteAllTodoSummaries(ctx)
+}
@@ -0,0 +1,33 @@
+package verify
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	logHead = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "todoapp_event_log_head_sequence",
+		Help: "The greatest sequence of todo_events",
+	})
+
+	violations = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "todoapp_event_log_violations",
+		Help: "Number of integrity violations found by the last verification",
+	}, []string{"kind"})
+
+	verifyErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "todoapp_event_log_verify_errors_total",
+		Help: "Number of verifications failed to run",
+	})
+)
+
+func observeReport(report Report) {
+	logHead.Set(float64(report.Head))
+
+	violations.WithLabelValues("gap").Set(float64(len(report.Gaps)))
+	violations.WithLabelValues("duplicate").Set(float64(len(report.Duplicates)))
+	violations.WithLabelValues("out_of_order").Set(float64(len(report.OutOfOrder)))
+	violations.WithLabelValues("stale").Set(float64(len(report.StaleEvents)))
+	violations.WithLabelValues("publisher_ahead").Set(float64(len(report.AheadPublishers)))
+}
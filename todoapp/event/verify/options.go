@@ -0,0 +1,59 @@
+package verify
+
+import "time"
+
+// Option ...
+type Option func(opts *verifierOpts)
+
+type verifierOpts struct {
+	interval   time.Duration
+	staleAfter time.Duration
+	limit      uint64
+	now        func() time.Time
+
+	archivedSequence func() (uint64, error)
+}
+
+var defaultVerifierOpts = verifierOpts{
+	interval:   5 * time.Minute,
+	staleAfter: 1 * time.Minute,
+	limit:      100,
+	now:        time.Now,
+
+	archivedSequence: func() (uint64, error) { return 0, nil },
+}
+
+// WithInterval ...
+func WithInterval(d time.Duration) Option {
+	return func(opts *verifierOpts) {
+		opts.interval = d
+	}
+}
+
+// WithStaleAfter events having NULL sequence longer than d are reported
+func WithStaleAfter(d time.Duration) Option {
+	return func(opts *verifierOpts) {
+		opts.staleAfter = d
+	}
+}
+
+// WithReportLimit limits the number of rows reported for each kind of violation
+func WithReportLimit(limit uint64) Option {
+	return func(opts *verifierOpts) {
+		opts.limit = limit
+	}
+}
+
+// WithArchivedSequence sets the greatest archived sequence,
+// the log is expected to continue right after it instead of starting at 1
+func WithArchivedSequence(fn func() (uint64, error)) Option {
+	return func(opts *verifierOpts) {
+		opts.archivedSequence = fn
+	}
+}
+
+func applyOptions(opts *verifierOpts, options ...Option) {
+	for _, o := range options {
+		o(opts)
+	}
+}
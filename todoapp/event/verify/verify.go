@@ -0,0 +1,238 @@
+//go:generate mockgen -destination=../../mocks/verify_repository.go -package=types_mocks -mock_names=Repository=MockVerifyRepository . Repository
+
+package verify
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+type (
+	// SequenceGap a range of missing sequences, inclusive
+	SequenceGap struct {
+		FromSequence uint64 `db:"from_sequence"`
+		ToSequence   uint64 `db:"to_sequence"`
+	}
+
+	// DuplicatedSequence a sequence used by more than one event
+	DuplicatedSequence struct {
+		Sequence uint64 `db:"sequence"`
+		Count    uint64 `db:"count"`
+	}
+
+	// OutOfOrderEvent an event having a smaller sequence than the event with the previous id
+	OutOfOrderEvent struct {
+		ID           uint64 `db:"id"`
+		Sequence     uint64 `db:"sequence"`
+		PrevID       uint64 `db:"prev_id"`
+		PrevSequence uint64 `db:"prev_sequence"`
+	}
+
+	// StaleEvent an event that has not been assigned a sequence for too long
+	StaleEvent struct {
+		ID        uint64    `db:"id"`
+		CreatedAt time.Time `db:"created_at"`
+	}
+
+	// PublisherSequence the last sequence saved by a publisher
+	PublisherSequence struct {
+		ID       uint32 `db:"id"`
+		Sequence uint64 `db:"sequence"`
+	}
+)
+
+// Repository ...
+type Repository interface {
+	GetLogHead(ctx context.Context) (uint64, error)
+	GetFirstSequence(ctx context.Context) (uint64, error)
+	FindSequenceGaps(ctx context.Context, limit uint64) ([]SequenceGap, error)
+	FindDuplicatedSequences(ctx context.Context, limit uint64) ([]DuplicatedSequence, error)
+	FindOutOfOrderEvents(ctx context.Context, limit uint64) ([]OutOfOrderEvent, error)
+	FindStaleUnprocessedEvents(ctx context.Context, before time.Time, limit uint64) ([]StaleEvent, error)
+	GetPublisherSequences(ctx context.Context) ([]PublisherSequence, error)
+}
+
+// Report the result of a verification
+type Report struct {
+	Head            uint64
+	Gaps            []SequenceGap
+	Duplicates      []DuplicatedSequence
+	OutOfOrder      []OutOfOrderEvent
+	StaleEvents     []StaleEvent
+	AheadPublishers []PublisherSequence
+}
+
+// OK returns true when no violation is found
+func (r Report) OK() bool {
+	return len(r.Gaps) == 0 &&
+		len(r.Duplicates) == 0 &&
+		len(r.OutOfOrder) == 0 &&
+		len(r.StaleEvents) == 0 &&
+		len(r.AheadPublishers) == 0
+}
+
+// Verifier checks the integrity of the persisted event log
+type Verifier struct {
+	repo   Repository
+	logger *zap.Logger
+
+	// options
+	interval   time.Duration
+	staleAfter time.Duration
+	limit      uint64
+	now        func() time.Time
+
+	archivedSequence func() (uint64, error)
+}
+
+// NewVerifier ...
+func NewVerifier(repo Repository, logger *zap.Logger, options ...Option) *Verifier {
+	opts := defaultVerifierOpts
+	applyOptions(&opts, options...)
+
+	return &Verifier{
+		repo:   repo,
+		logger: logger,
+
+		interval:   opts.interval,
+		staleAfter: opts.staleAfter,
+		limit:      opts.limit,
+		now:        opts.now,
+
+		archivedSequence: opts.archivedSequence,
+	}
+}
+
+func findAheadPublishers(head uint64, publishers []PublisherSequence) []PublisherSequence {
+	var result []PublisherSequence
+	for _, p := range publishers {
+		if p.Sequence > head {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// findLeadingGap returns the sequences missing between the archive and the first sequence of the log
+func findLeadingGap(archived uint64, first uint64) []SequenceGap {
+	if first == 0 || first <= archived+1 {
+		return nil
+	}
+	return []SequenceGap{{FromSequence: archived + 1, ToSequence: first - 1}}
+}
+
+// Verify scans the event log once
+func (v *Verifier) Verify(ctx context.Context) (Report, error) {
+	head, err := v.repo.GetLogHead(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	first, err := v.repo.GetFirstSequence(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	archived, err := v.archivedSequence()
+	if err != nil {
+		return Report{}, err
+	}
+
+	gaps, err := v.repo.FindSequenceGaps(ctx, v.limit)
+	if err != nil {
+		return Report{}, err
+	}
+	gaps = append(findLeadingGap(archived, first), gaps...)
+
+	duplicates, err := v.repo.FindDuplicatedSequences(ctx, v.limit)
+	if err != nil {
+		return Report{}, err
+	}
+
+	outOfOrder, err := v.repo.FindOutOfOrderEvents(ctx, v.limit)
+	if err != nil {
+		return Report{}, err
+	}
+
+	staleEvents, err := v.repo.FindStaleUnprocessedEvents(ctx, v.now().Add(-v.staleAfter), v.limit)
+	if err != nil {
+		return Report{}, err
+	}
+
+	publishers, err := v.repo.GetPublisherSequences(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	return Report{
+		Head:            head,
+		Gaps:            gaps,
+		Duplicates:      duplicates,
+		OutOfOrder:      outOfOrder,
+		StaleEvents:     staleEvents,
+		AheadPublishers: findAheadPublishers(head, publishers),
+	}, nil
+}
+
+// LogReport writes the report to logs and metrics
+func (v *Verifier) LogReport(report Report) {
+	observeReport(report)
+
+	if report.OK() {
+		v.logger.Info("Event log verified", zap.Uint64("head", report.Head))
+		return
+	}
+
+	for _, gap := range report.Gaps {
+		v.logger.Error("Event log sequence gap",
+			zap.Uint64("from_sequence", gap.FromSequence),
+			zap.Uint64("to_sequence", gap.ToSequence),
+		)
+	}
+	for _, d := range report.Duplicates {
+		v.logger.Error("Event log duplicated sequence",
+			zap.Uint64("sequence", d.Sequence),
+			zap.Uint64("count", d.Count),
+		)
+	}
+	for _, e := range report.OutOfOrder {
+		v.logger.Error("Event log sequence order disagrees with id order",
+			zap.Uint64("id", e.ID), zap.Uint64("sequence", e.Sequence),
+			zap.Uint64("prev_id", e.PrevID), zap.Uint64("prev_sequence", e.PrevSequence),
+		)
+	}
+	for _, e := range report.StaleEvents {
+		v.logger.Error("Event log stale unprocessed event",
+			zap.Uint64("id", e.ID), zap.Time("created_at", e.CreatedAt),
+		)
+	}
+	for _, p := range report.AheadPublishers {
+		v.logger.Error("Event log publisher ahead of log head",
+			zap.Uint32("publisher_id", p.ID),
+			zap.Uint64("sequence", p.Sequence),
+			zap.Uint64("head", report.Head),
+		)
+	}
+}
+
+// Run verifies the event log periodically
+func (v *Verifier) Run(ctx context.Context) {
+	for {
+		report, err := v.Verify(ctx)
+		if err != nil {
+			verifyErrors.Inc()
+			v.logger.Error("verifier.Verify", zap.Error(err))
+		} else {
+			v.LogReport(report)
+		}
+
+		select {
+		case <-time.After(v.interval):
+			continue
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package verify_test
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todoapp/todoapp/event/verify"
+	types_mocks "todoapp/todoapp/mocks"
+)
+
+func TestVerifier_Verify(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2020, 12, 20, 10, 0, 0, 0, time.UTC)
+
+	repo := types_mocks.NewMockVerifyRepository(ctrl)
+	v := verify.NewVerifier(repo, nil, verify.WithReportLimit(20))
+
+	gaps := []verify.SequenceGap{{FromSequence: 5, ToSequence: 6}}
+	stale := []verify.StaleEvent{{ID: 100, CreatedAt: now.Add(-time.Hour)}}
+
+	repo.EXPECT().GetLogHead(gomock.Any()).Return(uint64(30), nil)
+	repo.EXPECT().GetFirstSequence(gomock.Any()).Return(uint64(1), nil)
+	repo.EXPECT().FindSequenceGaps(gomock.Any(), uint64(20)).Return(gaps, nil)
+	repo.EXPECT().FindDuplicatedSequences(gomock.Any(), uint64(20)).Return(nil, nil)
+	repo.EXPECT().FindOutOfOrderEvents(gomock.Any(), uint64(20)).Return(nil, nil)
+	repo.EXPECT().FindStaleUnprocessedEvents(gomock.Any(), gomock.Any(), uint64(20)).
+		Return(stale, nil)
+	repo.EXPECT().GetPublisherSequences(gomock.Any()).Return([]verify.PublisherSequence{
+		{ID: 1, Sequence: 30},
+		{ID: 2, Sequence: 31},
+	}, nil)
+
+	report, err := v.Verify(context.Background())
+	assert.Nil(t, err)
+	assert.False(t, report.OK())
+	assert.Equal(t, verify.Report{
+		Head:            30,
+		Gaps:            gaps,
+		StaleEvents:     stale,
+		AheadPublishers: []verify.PublisherSequence{{ID: 2, Sequence: 31}},
+	}, report)
+}
+
+func TestVerifier_Verify_Error(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockVerifyRepository(ctrl)
+	v := verify.NewVerifier(repo, nil)
+
+	repo.EXPECT().GetLogHead(gomock.Any()).Return(uint64(0), errors.New("some error"))
+
+	_, err := v.Verify(context.Background())
+	assert.Equal(t, errors.New("some error"), err)
+}
+
+func TestVerifier_Verify_LeadingGap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := types_mocks.NewMockVerifyRepository(ctrl)
+	v := verify.NewVerifier(repo, nil, verify.WithArchivedSequence(func() (uint64, error) {
+		return 10, nil
+	}))
+
+	repo.EXPECT().GetLogHead(gomock.Any()).Return(uint64(30), nil)
+	repo.EXPECT().GetFirstSequence(gomock.Any()).Return(uint64(15), nil)
+	repo.EXPECT().FindSequenceGaps(gomock.Any(), gomock.Any()).
+		Return([]verify.SequenceGap{{FromSequence: 20, ToSequence: 20}}, nil)
+	repo.EXPECT().FindDuplicatedSequences(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().FindOutOfOrderEvents(gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().FindStaleUnprocessedEvents(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, nil)
+	repo.EXPECT().GetPublisherSequences(gomock.Any()).Return(nil, nil)
+
+	report, err := v.Verify(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []verify.SequenceGap{
+		{FromSequence: 11, ToSequence: 14},
+		{FromSequence: 20, ToSequence: 20},
+	}, report.Gaps)
+}
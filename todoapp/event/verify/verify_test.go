@@ -0,0 +1,36 @@
+package verify
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFindAheadPublishers(t *testing.T) {
+	result := findAheadPublishers(10, []PublisherSequence{
+		{ID: 1, Sequence: 10},
+		{ID: 2, Sequence: 11},
+		{ID: 3, Sequence: 3},
+	})
+	assert.Equal(t, []PublisherSequence{{ID: 2, Sequence: 11}}, result)
+}
+
+func TestFindLeadingGap(t *testing.T) {
+	table := []struct {
+		name     string
+		archived uint64
+		first    uint64
+		expected []SequenceGap
+	}{
+		{name: "empty-log", archived: 0, first: 0},
+		{name: "starts-at-one", archived: 0, first: 1},
+		{name: "missing-start", archived: 0, first: 4, expected: []SequenceGap{{FromSequence: 1, ToSequence: 3}}},
+		{name: "continues-archive", archived: 10, first: 11},
+		{name: "missing-after-archive", archived: 10, first: 13, expected: []SequenceGap{{FromSequence: 11, ToSequence: 12}}},
+		{name: "overlaps-archive", archived: 10, first: 8},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			assert.Equal(t, e.expected, findLeadingGap(e.archived, e.first))
+		})
+	}
+}
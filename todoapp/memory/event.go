@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/types"
+)
+
+// EventRepository ...
+type EventRepository struct {
+	store *Store
+}
+
+var _ core.Repository = &EventRepository{}
+
+// NewEventRepository ...
+func NewEventRepository(store *Store) *EventRepository {
+	return &EventRepository{
+		store: store,
+	}
+}
+
+func modelEventsToCore(events []model.Event) []core.Event {
+	result := make([]core.Event, 0, len(events))
+	for _, e := range events {
+		result = append(result, core.Event(types.EventFromModel(e)))
+	}
+	return result
+}
+
+// sequencedEvents returns events having sequence, ordered by sequence
+func (s *state) sequencedEvents() []model.Event {
+	var result []model.Event
+	for _, e := range s.events {
+		if e.Sequence.Valid {
+			result = append(result, e)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Sequence.Int64 < result[j].Sequence.Int64
+	})
+	return result
+}
+
+// GetLastEvents ...
+func (r *EventRepository) GetLastEvents(limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	r.store.access(func(st *state) {
+		events = st.sequencedEvents()
+	})
+
+	if uint64(len(events)) > limit {
+		events = events[uint64(len(events))-limit:]
+	}
+	return modelEventsToCore(events), nil
+}
+
+// GetEventsFromSequence ...
+func (r *EventRepository) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	r.store.access(func(st *state) {
+		for _, e := range st.sequencedEvents() {
+			if uint64(len(events)) >= limit {
+				break
+			}
+			if uint64(e.Sequence.Int64) >= seq {
+				events = append(events, e)
+			}
+		}
+	})
+	return modelEventsToCore(events), nil
+}
+
+// GetUnprocessedEvents ...
+func (r *EventRepository) GetUnprocessedEvents(limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	r.store.access(func(st *state) {
+		for _, e := range st.events {
+			if uint64(len(events)) >= limit {
+				break
+			}
+			if !e.Sequence.Valid {
+				events = append(events, e)
+			}
+		}
+	})
+	return modelEventsToCore(events), nil
+}
+
+// GetLastSequence ...
+func (r *EventRepository) GetLastSequence(id core.PublisherID) (uint64, error) {
+	var result uint64
+	r.store.access(func(st *state) {
+		result = st.publishers[id]
+	})
+	return result, nil
+}
+
+// SaveLastSequence ...
+func (r *EventRepository) SaveLastSequence(id core.PublisherID, seq uint64) error {
+	r.store.access(func(st *state) {
+		st.publishers[id] = seq
+	})
+	return nil
+}
+
+// UpdateSequences ...
+func (r *EventRepository) UpdateSequences(events []core.Event) error {
+	sequences := make(map[model.EventID]uint64, len(events))
+	for _, e := range events {
+		sequences[e.ID] = e.Sequence
+	}
+
+	r.store.access(func(st *state) {
+		for i, e := range st.events {
+			seq, existed := sequences[e.ID]
+			if existed {
+				st.events[i].Sequence.Valid = true
+				st.events[i].Sequence.Int64 = int64(seq)
+			}
+		}
+	})
+	return nil
+}
@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+)
+
+type state struct {
+	todos      map[model.TodoID]model.Todo
+	items      map[model.TodoItemID]model.TodoItem
+	events     []model.Event
+	publishers map[core.PublisherID]uint64
+
+	lastTodoID  model.TodoID
+	lastItemID  model.TodoItemID
+	lastEventID model.EventID
+}
+
+func newState() *state {
+	return &state{
+		todos:      make(map[model.TodoID]model.Todo),
+		items:      make(map[model.TodoItemID]model.TodoItem),
+		publishers: make(map[core.PublisherID]uint64),
+	}
+}
+
+func (s *state) clone() *state {
+	result := &state{
+		todos:      make(map[model.TodoID]model.Todo, len(s.todos)),
+		items:      make(map[model.TodoItemID]model.TodoItem, len(s.items)),
+		events:     make([]model.Event, len(s.events)),
+		publishers: make(map[core.PublisherID]uint64, len(s.publishers)),
+
+		lastTodoID:  s.lastTodoID,
+		lastItemID:  s.lastItemID,
+		lastEventID: s.lastEventID,
+	}
+
+	for id, todo := range s.todos {
+		result.todos[id] = todo
+	}
+	for id, item := range s.items {
+		result.items[id] = item
+	}
+	copy(result.events, s.events)
+	for id, seq := range s.publishers {
+		result.publishers[id] = seq
+	}
+	return result
+}
+
+// Store holds todos, todo items, events and publisher sequences in memory.
+// Transactions are serialized, each one works on a copy of the data
+// that replaces the current data only when committed
+type Store struct {
+	mut   sync.Mutex
+	state *state
+}
+
+// NewStore ...
+func NewStore() *Store {
+	return &Store{
+		state: newState(),
+	}
+}
+
+func (s *Store) transact(ctx context.Context, fn func(st *state) error) error {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	st := s.state.clone()
+	err := fn(st)
+	if err != nil {
+		return err
+	}
+
+	s.state = st
+	return nil
+}
+
+// access runs fn outside of any transaction
+func (s *Store) access(fn func(st *state)) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	fn(s.state)
+}
@@ -0,0 +1,91 @@
+package memory_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/event/notify"
+	"todoapp/todoapp/memory"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/service"
+	"todoapp/todoapp/types"
+)
+
+func TestRepository_Transact_Rollback(t *testing.T) {
+	store := memory.NewStore()
+	repo := memory.NewRepository(store)
+	ctx := context.Background()
+
+	var todoID model.TodoID
+	err := repo.Transact(ctx, func(tx types.TxnRepository) error {
+		id, err := tx.InsertTodo(ctx, model.Todo{Name: "some todo"})
+		todoID = id
+		assert.Nil(t, err)
+		return errors.New("some error")
+	})
+	assert.Equal(t, errors.New("some error"), err)
+
+	err = repo.Transact(ctx, func(tx types.TxnRepository) error {
+		nullTodo, err := tx.GetTodo(ctx, todoID)
+		assert.Nil(t, err)
+		assert.False(t, nullTodo.Valid)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+type collectPublisher struct {
+	events chan []core.Event
+}
+
+func (p *collectPublisher) GetID() core.PublisherID {
+	return 1
+}
+
+func (p *collectPublisher) Publish(events []core.Event) error {
+	p.events <- events
+	return nil
+}
+
+func TestSaveTodo_Published(t *testing.T) {
+	store := memory.NewStore()
+	channel := notify.NewChannel()
+	publisher := &collectPublisher{events: make(chan []core.Event, 10)}
+
+	eventCore := core.NewCore(memory.NewEventRepository(store),
+		core.WithErrorTimeout(time.Second),
+		core.AddNotifier(channel),
+		core.AddPublisher(publisher),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go eventCore.Run(ctx)
+
+	s := service.NewService(memory.NewRepository(store), channel)
+	id, err := s.SaveTodo(ctx, types.SaveTodoInput{
+		Name: "some todo",
+		Items: []model.TodoItem{
+			{Name: "some item"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, model.TodoID(1), id)
+
+	events := <-publisher.events
+	assert.Equal(t, 1, len(events))
+	assert.Equal(t, uint64(1), events[0].Sequence)
+	assert.Equal(t, uint64(1), events[0].Data.TodoSave.Id)
+	assert.Equal(t, "some todo", events[0].Data.TodoSave.Name)
+
+	err = memory.NewRepository(store).Transact(ctx, func(tx types.TxnRepository) error {
+		items, err := tx.GetTodoItemsByTodoID(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.TodoItem{{ID: 1, TodoID: 1, Name: "some item"}}, items)
+		return nil
+	})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/types"
+)
+
+type (
+	// Repository ...
+	Repository struct {
+		store *Store
+	}
+
+	// TxnRepository ...
+	TxnRepository struct {
+		state *state
+	}
+
+	// EventTxnRepository ...
+	EventTxnRepository struct {
+		state *state
+	}
+)
+
+var _ types.Repository = &Repository{}
+
+var _ types.TxnRepository = &TxnRepository{}
+
+var _ types.EventTxnRepository = &EventTxnRepository{}
+
+// NewRepository ...
+func NewRepository(store *Store) *Repository {
+	return &Repository{
+		store: store,
+	}
+}
+
+// Transact rollbacks all the changes when fn returns an error
+func (r *Repository) Transact(ctx context.Context, fn func(tx types.TxnRepository) error) error {
+	return r.store.transact(ctx, func(st *state) error {
+		return fn(&TxnRepository{state: st})
+	})
+}
+
+// GetTodo ...
+func (r *TxnRepository) GetTodo(_ context.Context, id model.TodoID) (model.NullTodo, error) {
+	todo, existed := r.state.todos[id]
+	if !existed {
+		return model.NullTodo{}, nil
+	}
+	return model.NullTodo{Valid: true, Todo: todo}, nil
+}
+
+// InsertTodo ...
+func (r *TxnRepository) InsertTodo(_ context.Context, save model.Todo) (model.TodoID, error) {
+	r.state.lastTodoID++
+	save.ID = r.state.lastTodoID
+	r.state.todos[save.ID] = save
+	return save.ID, nil
+}
+
+// UpdateTodo ...
+func (r *TxnRepository) UpdateTodo(_ context.Context, save model.Todo) error {
+	todo, existed := r.state.todos[save.ID]
+	if !existed {
+		return nil
+	}
+	todo.Name = save.Name
+	r.state.todos[save.ID] = todo
+	return nil
+}
+
+// GetTodoItemsByTodoID ...
+func (r *TxnRepository) GetTodoItemsByTodoID(_ context.Context, todoID model.TodoID,
+) ([]model.TodoItem, error) {
+	var result []model.TodoItem
+	for _, item := range r.state.items {
+		if item.TodoID == todoID {
+			result = append(result, item)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+// DeleteTodoItems ...
+func (r *TxnRepository) DeleteTodoItems(_ context.Context, todoItemIDs []model.TodoItemID) error {
+	for _, id := range todoItemIDs {
+		delete(r.state.items, id)
+	}
+	return nil
+}
+
+// InsertTodoItem ...
+func (r *TxnRepository) InsertTodoItem(_ context.Context, save model.TodoItem) (model.TodoItemID, error) {
+	r.state.lastItemID++
+	save.ID = r.state.lastItemID
+	r.state.items[save.ID] = save
+	return save.ID, nil
+}
+
+// UpdateTodoITem ...
+func (r *TxnRepository) UpdateTodoITem(_ context.Context, save model.TodoItem) error {
+	item, existed := r.state.items[save.ID]
+	if !existed {
+		return nil
+	}
+	item.Name = save.Name
+	r.state.items[save.ID] = item
+	return nil
+}
+
+// ToEventRepository ...
+func (r *TxnRepository) ToEventRepository() types.EventTxnRepository {
+	return &EventTxnRepository{state: r.state}
+}
+
+// InsertEvent ...
+func (r *EventTxnRepository) InsertEvent(_ context.Context, event model.Event) (model.EventID, error) {
+	r.state.lastEventID++
+	r.state.events = append(r.state.events, model.Event{
+		ID:          r.state.lastEventID,
+		AggregateID: event.AggregateID,
+		Data:        event.Data,
+		CreatedAt:   time.Now(),
+	})
+	return r.state.lastEventID, nil
+}
@@ -10,8 +10,15 @@ type EventID uint64
 
 // Event ...
 type Event struct {
-	ID        EventID       `db:"id"`
-	Sequence  sql.NullInt64 `db:"sequence"`
-	Data      string        `db:"data"`
-	CreatedAt time.Time     `db:"created_at"`
+	ID          EventID       `db:"id"`
+	AggregateID sql.NullInt64 `db:"aggregate_id"` // null for events not changing an aggregate
+	Sequence    sql.NullInt64 `db:"sequence"`
+	Data        string        `db:"data"`
+	CreatedAt   time.Time     `db:"created_at"`
+}
+
+// NullEvent ...
+type NullEvent struct {
+	Valid bool
+	Event Event
 }
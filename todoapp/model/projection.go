@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// TodoSummary the denormalized read model of a todo, maintained by the projector
+type TodoSummary struct {
+	ID           TodoID    `db:"id"`
+	Name         string    `db:"name"`
+	ItemCount    uint32    `db:"item_count"`
+	LastSequence uint64    `db:"last_sequence"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
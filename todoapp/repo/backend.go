@@ -0,0 +1,110 @@
+package repo
+
+import (
+	"github.com/jmoiron/sqlx"
+	"todoapp/config"
+	"todoapp/lib/dblib"
+	"todoapp/lib/mysql"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/memory"
+	"todoapp/todoapp/types"
+
+	lib_postgres "todoapp/lib/postgres"
+	lib_sqlite "todoapp/lib/sqlite"
+	"todoapp/todoapp/repo/postgres"
+	"todoapp/todoapp/repo/sqlite"
+)
+
+// DialectMemory the database.driver keeping the data in the memory store of the process, nothing is persisted.
+// The repositories of a nil db use that store, so the event core MUST run in the API server with the channel notifier
+const DialectMemory dblib.Dialect = "memory"
+
+var memoryStore = memory.NewStore()
+
+// MustConnect connects to the database selected by database.driver
+// and configures the compression of the event payloads stored in it, returns nil for the memory driver,
+// MUST import the database/sql driver of the backend in main.go
+func MustConnect(conf config.Config) *sqlx.DB {
+	mustSetEventCompression(conf.Event.Compression)
+
+	switch Dialect(conf) {
+	case DialectMemory:
+		return nil
+	case dblib.DialectMySQL:
+		return mysql.MustConnect(conf.MySQL)
+	case dblib.DialectSQLite:
+		return lib_sqlite.MustConnect(conf.SQLite)
+	case dblib.DialectPostgres:
+		return lib_postgres.MustConnect(conf.Postgres)
+	default:
+		panic("Invalid database driver")
+	}
+}
+
+func mustSetEventCompression(conf config.EventCompression) {
+	codec, err := types.ParseCodec(conf.Codec)
+	if err != nil {
+		panic(err)
+	}
+	types.SetEventCompression(types.EventCompression{
+		Codec:     codec,
+		Threshold: conf.Threshold,
+	})
+}
+
+// Dialect returns the dialect of the database selected by database.driver, default is MySQL
+func Dialect(conf config.Config) dblib.Dialect {
+	if conf.Database.Driver == "" {
+		return dblib.DialectMySQL
+	}
+	return dblib.Dialect(conf.Database.Driver)
+}
+
+// NewTodoRepository creates the types.Repository of the backend of db
+func NewTodoRepository(db *sqlx.DB) types.Repository {
+	if db == nil {
+		return memory.NewRepository(memoryStore)
+	}
+
+	switch dblib.Dialect(db.DriverName()) {
+	case dblib.DialectSQLite:
+		return sqlite.NewRepository(db)
+	case dblib.DialectPostgres:
+		return postgres.NewRepository(db)
+	default:
+		return NewRepository(db)
+	}
+}
+
+// NewCoreRepository creates the core.Repository of the backend of db
+func NewCoreRepository(db *sqlx.DB) core.Repository {
+	if db == nil {
+		return memory.NewEventRepository(memoryStore)
+	}
+
+	switch dblib.Dialect(db.DriverName()) {
+	case dblib.DialectSQLite:
+		return sqlite.NewEventRepository(db)
+	case dblib.DialectPostgres:
+		return postgres.NewEventRepository(db)
+	default:
+		return NewEventRepository(db)
+	}
+}
+
+// NewTodoHistoryRepository creates the types.TodoHistoryRepository of the backend of db,
+// nil for the memory driver, the todo history is then unimplemented
+func NewTodoHistoryRepository(db *sqlx.DB) types.TodoHistoryRepository {
+	if db == nil {
+		return nil
+	}
+
+	switch dblib.Dialect(db.DriverName()) {
+	case dblib.DialectSQLite:
+		return sqlite.NewHistoryRepository(db)
+	case dblib.DialectPostgres:
+		return postgres.NewHistoryRepository(db)
+	default:
+		return NewHistoryRepository(db)
+	}
+}
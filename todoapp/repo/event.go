@@ -8,6 +8,7 @@ import (
 	"strings"
 	"todoapp/lib/dblib"
 	"todoapp/pkg/errors"
+	"todoapp/todoapp/event/archive"
 	"todoapp/todoapp/event/core"
 	"todoapp/todoapp/model"
 	"todoapp/todoapp/types"
@@ -15,7 +16,8 @@ import (
 
 // EventRepository ...
 type EventRepository struct {
-	db *sqlx.DB
+	db      *sqlx.DB
+	archive *archive.Store
 }
 
 // EventTxnRepository ...
@@ -33,6 +35,15 @@ func NewEventRepository(db *sqlx.DB) *EventRepository {
 	}
 }
 
+// NewArchivedEventRepository creates an EventRepository that reads archived events
+// when a publisher rewinds past the live table
+func NewArchivedEventRepository(db *sqlx.DB, store *archive.Store) *EventRepository {
+	return &EventRepository{
+		db:      db,
+		archive: store,
+	}
+}
+
 // NewEventTxnRepository ...
 func NewEventTxnRepository(tx *sqlx.Tx) *EventTxnRepository {
 	return &EventTxnRepository{
@@ -76,15 +87,30 @@ LIMIT ?
 `)
 
 // GetEventsFromSequence ...
-func (r *EventRepository) GetEventsFromSequence(seq uint64, limit uint64) ([]core.Event, error) {
+func (r *EventRepository) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]core.Event, error) {
 	var events []model.Event
-	err := r.db.Select(&events, getEventsFromSequenceQuery, seq, limit)
+	err := r.db.SelectContext(ctx, &events, getEventsFromSequenceQuery, seq, limit)
 	if err != nil {
 		return nil, err
 	}
+
+	if r.archive != nil && !startsWithSequence(events, seq) {
+		archived, err := r.archive.Read(seq, limit)
+		if err != nil {
+			return nil, err
+		}
+		if len(archived) > 0 {
+			events = archived
+		}
+	}
+
 	return modelEventsToCore(events), nil
 }
 
+func startsWithSequence(events []model.Event, seq uint64) bool {
+	return len(events) > 0 && uint64(events[0].Sequence.Int64) == seq
+}
+
 var getUnprocessedEventsQuery = dblib.NewQuery(`
 SELECT id, data, created_at FROM todo_events
 WHERE sequence IS NULL
@@ -104,7 +130,7 @@ func (r *EventRepository) GetUnprocessedEvents(limit uint64) ([]core.Event, erro
 
 var getLastSequenceQuery = dblib.NewQuery(`
 SELECT sequence FROM todo_publishers
-WHERE id = ?
+WHERE id = ? AND partition_id = 0
 `)
 
 // GetLastSequence ...
@@ -162,13 +188,16 @@ func (r *EventRepository) UpdateSequences(events []core.Event) error {
 	return err
 }
 
+// the partition is aggregate_id % the count stored by CheckPartitionCount,
+// 0 for events without aggregate_id or before the count is stored
 var insertEventQuery = dblib.NewQuery(`
-INSERT INTO todo_events (data) VALUES (?)
+INSERT INTO todo_events (aggregate_id, partition_id, data)
+SELECT ?, COALESCE(? % (SELECT partition_count FROM todo_event_settings WHERE id = 1), 0), ?
 `)
 
 // InsertEvent ...
 func (r *EventTxnRepository) InsertEvent(ctx context.Context, event model.Event) (model.EventID, error) {
-	res, err := r.tx.ExecContext(ctx, insertEventQuery, event.Data)
+	res, err := r.tx.ExecContext(ctx, insertEventQuery, event.AggregateID, event.AggregateID, event.Data)
 	if err != nil {
 		return 0, errors.WrapDBError(ctx, err)
 	}
@@ -0,0 +1,127 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todoapp/lib/dblib"
+	"todoapp/pkg/errors"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/types"
+)
+
+// HistoryRepository ...
+type HistoryRepository struct {
+	db *sqlx.DB
+}
+
+var _ types.TodoHistoryRepository = &HistoryRepository{}
+
+// NewHistoryRepository ...
+func NewHistoryRepository(db *sqlx.DB) *HistoryRepository {
+	return &HistoryRepository{
+		db: db,
+	}
+}
+
+var getTodoEventsQuery = dblib.NewQuery(`
+SELECT e.id, e.aggregate_id, e.sequence, e.data, e.created_at FROM (
+	SELECT id, aggregate_id, sequence, data, created_at FROM todo_events
+	WHERE aggregate_id = ?
+	ORDER BY id DESC
+	LIMIT ?
+) e ORDER BY e.id ASC
+`)
+
+// GetTodoEvents ...
+func (r *HistoryRepository) GetTodoEvents(ctx context.Context, todoID model.TodoID, limit uint64,
+) ([]model.Event, error) {
+	var result []model.Event
+	err := r.db.SelectContext(ctx, &result, getTodoEventsQuery, todoID, limit)
+	if err != nil {
+		return nil, errors.WrapDBError(ctx, err)
+	}
+	return result, nil
+}
+
+var getTodoEventBySequenceQuery = dblib.NewQuery(`
+SELECT id, aggregate_id, sequence, data, created_at FROM todo_events
+WHERE aggregate_id = ? AND sequence <= ?
+ORDER BY sequence DESC
+LIMIT 1
+`)
+
+// GetTodoEventBySequence ...
+func (r *HistoryRepository) GetTodoEventBySequence(ctx context.Context, todoID model.TodoID, sequence uint64,
+) (model.NullEvent, error) {
+	return r.getTodoEvent(ctx, getTodoEventBySequenceQuery, todoID, sequence)
+}
+
+var getTodoEventByTimeQuery = dblib.NewQuery(`
+SELECT id, aggregate_id, sequence, data, created_at FROM todo_events
+WHERE aggregate_id = ? AND created_at <= ?
+ORDER BY id DESC
+LIMIT 1
+`)
+
+// GetTodoEventByTime ...
+func (r *HistoryRepository) GetTodoEventByTime(ctx context.Context, todoID model.TodoID, at time.Time,
+) (model.NullEvent, error) {
+	return r.getTodoEvent(ctx, getTodoEventByTimeQuery, todoID, at)
+}
+
+func (r *HistoryRepository) getTodoEvent(ctx context.Context, query string, args ...interface{},
+) (model.NullEvent, error) {
+	var event model.Event
+	err := r.db.GetContext(ctx, &event, query, args...)
+	if err == sql.ErrNoRows {
+		return model.NullEvent{}, nil
+	}
+	if err != nil {
+		return model.NullEvent{}, errors.WrapDBError(ctx, err)
+	}
+	return model.NullEvent{Valid: true, Event: event}, nil
+}
+
+var getEventsWithoutAggregateIDQuery = dblib.NewQuery(`
+SELECT id, sequence, data, created_at FROM todo_events
+WHERE aggregate_id IS NULL AND id > ?
+ORDER BY id ASC
+LIMIT ?
+`)
+
+var setAggregateIDQuery = dblib.NewQuery(`
+UPDATE todo_events SET aggregate_id = ? WHERE id = ?
+`)
+
+// BackfillAggregateIDs sets aggregate_id of the events inserted before the column existed,
+// returns the number of updated events, events not changing an aggregate stay null
+func (r *HistoryRepository) BackfillAggregateIDs(ctx context.Context, batchSize uint64) (int, error) {
+	count := 0
+	lastID := model.EventID(0)
+	for {
+		var events []model.Event
+		err := r.db.SelectContext(ctx, &events, getEventsWithoutAggregateIDQuery, lastID, batchSize)
+		if err != nil {
+			return count, err
+		}
+		if len(events) == 0 {
+			return count, nil
+		}
+
+		for _, e := range events {
+			lastID = e.ID
+
+			aggregateID := types.EventFromModel(e).AggregateID()
+			if !aggregateID.Valid {
+				continue
+			}
+			_, err := r.db.ExecContext(ctx, setAggregateIDQuery, aggregateID, e.ID)
+			if err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"todoapp/lib/dblib"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+)
+
+// PartitionEventRepository is the core.Repository of one partition of the todo events,
+// an event belongs to the partition aggregate_id % count set by InsertEvent, each partition has its own sequence
+type PartitionEventRepository struct {
+	db        *sqlx.DB
+	partition uint32
+	count     uint32
+}
+
+var _ core.Repository = &PartitionEventRepository{}
+
+// NewPartitionEventRepository ...
+func NewPartitionEventRepository(db *sqlx.DB, partition uint32, count uint32) *PartitionEventRepository {
+	if partition >= count {
+		panic("partition must be less than count")
+	}
+	return &PartitionEventRepository{
+		db:        db,
+		partition: partition,
+		count:     count,
+	}
+}
+
+var getPartitionLastEventsQuery = dblib.NewQuery(`
+SELECT e.id, e.sequence, e.data, e.created_at FROM (
+	SELECT id, sequence, data, created_at FROM todo_events
+	WHERE partition_id = ? AND sequence IS NOT NULL
+	ORDER BY sequence DESC
+	LIMIT ?
+) e ORDER BY sequence ASC
+`)
+
+// GetLastEvents ...
+func (r *PartitionEventRepository) GetLastEvents(limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	err := r.db.Select(&events, getPartitionLastEventsQuery, r.partition, limit)
+	if err != nil {
+		return nil, err
+	}
+	return modelEventsToCore(events), nil
+}
+
+var getPartitionEventsFromSequenceQuery = dblib.NewQuery(`
+SELECT id, sequence, data, created_at FROM todo_events
+WHERE partition_id = ? AND sequence >= ?
+ORDER BY sequence ASC
+LIMIT ?
+`)
+
+// GetEventsFromSequence ...
+func (r *PartitionEventRepository) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	err := r.db.SelectContext(ctx, &events, getPartitionEventsFromSequenceQuery, r.partition, seq, limit)
+	if err != nil {
+		return nil, err
+	}
+	return modelEventsToCore(events), nil
+}
+
+// partition_id is set when the event is inserted, the query uses the index on (partition_id, sequence)
+var getPartitionUnprocessedEventsQuery = dblib.NewQuery(`
+SELECT id, data, created_at FROM todo_events
+WHERE partition_id = ? AND sequence IS NULL
+ORDER BY id ASC
+LIMIT ?
+`)
+
+// GetUnprocessedEvents ...
+func (r *PartitionEventRepository) GetUnprocessedEvents(limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	err := r.db.Select(&events, getPartitionUnprocessedEventsQuery, r.partition, limit)
+	if err != nil {
+		return nil, err
+	}
+	return modelEventsToCore(events), nil
+}
+
+var getPartitionLastSequenceQuery = dblib.NewQuery(`
+SELECT sequence FROM todo_publishers
+WHERE id = ? AND partition_id = ?
+`)
+
+// GetLastSequence ...
+func (r *PartitionEventRepository) GetLastSequence(id core.PublisherID) (uint64, error) {
+	var result uint64
+	err := r.db.Get(&result, getPartitionLastSequenceQuery, id, r.partition)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+var savePartitionLastSequenceQuery = dblib.NewQuery(`
+INSERT INTO todo_publishers (id, partition_id, sequence)
+VALUES (?, ?, ?) AS new
+ON DUPLICATE KEY UPDATE sequence = new.sequence
+`)
+
+// SaveLastSequence ...
+func (r *PartitionEventRepository) SaveLastSequence(id core.PublisherID, seq uint64) error {
+	_, err := r.db.Exec(savePartitionLastSequenceQuery, id, r.partition, seq)
+	return err
+}
+
+var updatePartitionSequencesQuery = `
+INSERT INTO todo_events (id, partition_id, sequence, data)
+VALUES %s AS new
+ON DUPLICATE KEY UPDATE partition_id = new.partition_id, sequence = new.sequence
+`
+
+var _ = dblib.NewQuery(fmt.Sprintf(updatePartitionSequencesQuery, "(?, ?, ?, '')"))
+
+// UpdateSequences ...
+func (r *PartitionEventRepository) UpdateSequences(events []core.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	var buf strings.Builder
+	args := make([]interface{}, 0, 3*len(events))
+	for i, e := range events {
+		if i == 0 {
+			buf.WriteString("(?, ?, ?, '')")
+		} else {
+			buf.WriteString(",(?, ?, ?, '')")
+		}
+		args = append(args, e.ID, r.partition, e.Sequence)
+	}
+
+	query := fmt.Sprintf(updatePartitionSequencesQuery, buf.String())
+	_, err := r.db.Exec(query, args...)
+	return err
+}
+
+var insertPartitionCountQuery = dblib.NewQuery(`
+INSERT IGNORE INTO todo_event_settings (id, partition_count)
+VALUES (1, ?)
+`)
+
+var getPartitionCountQuery = dblib.NewQuery(`
+SELECT partition_count FROM todo_event_settings
+WHERE id = 1
+`)
+
+// CheckPartitionCount records count on the first start and returns an error when the events are partitioned
+// by another count, the partition of an event is aggregate_id % count so changing it needs a migration
+// moving the sequences of the events and the publishers
+func CheckPartitionCount(db *sqlx.DB, count uint32) error {
+	_, err := db.Exec(insertPartitionCountQuery, count)
+	if err != nil {
+		return err
+	}
+
+	var stored uint32
+	err = db.Get(&stored, getPartitionCountQuery)
+	if err != nil {
+		return err
+	}
+	if stored != count {
+		return fmt.Errorf("events are partitioned by %d partitions, not %d", stored, count)
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"sync"
+	"time"
+	"todoapp/lib/dblib"
+	"todoapp/pkg/errors"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/types"
+)
+
+// EventRepository ...
+// GetUnprocessedEvents claims the returned events holding the sequence lock,
+// the claim is kept in a transaction until UpdateSequences commits the sequences,
+// so concurrent event processors never sequence the same event
+// and never number events from a stale last sequence.
+// A claim not committed within claimTimeout, like on errors or shutdown, is rolled back releasing the lock
+type EventRepository struct {
+	db *sqlx.DB
+
+	mut         sync.Mutex
+	claimTx     *sqlx.Tx
+	claimCancel context.CancelFunc
+}
+
+// EventTxnRepository ...
+type EventTxnRepository struct {
+	tx *sqlx.Tx
+}
+
+var _ core.Repository = &EventRepository{}
+var _ types.EventTxnRepository = &EventTxnRepository{}
+
+// NewEventRepository ...
+func NewEventRepository(db *sqlx.DB) *EventRepository {
+	return &EventRepository{
+		db: db,
+	}
+}
+
+// NewEventTxnRepository ...
+func NewEventTxnRepository(tx *sqlx.Tx) *EventTxnRepository {
+	return &EventTxnRepository{
+		tx: tx,
+	}
+}
+
+func modelEventsToCore(events []model.Event) []core.Event {
+	result := make([]core.Event, 0, len(events))
+	for _, e := range events {
+		event := core.Event(types.EventFromModel(e))
+		result = append(result, event)
+	}
+	return result
+}
+
+var getLastEventsQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT e.id, e.sequence, e.data, e.created_at FROM (
+	SELECT id, sequence, data, created_at FROM todo_events
+	WHERE sequence IS NOT NULL
+	ORDER BY sequence DESC
+	LIMIT $1
+) e ORDER BY sequence ASC
+`)
+
+// GetLastEvents ...
+func (r *EventRepository) GetLastEvents(limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	err := r.db.Select(&events, getLastEventsQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	return modelEventsToCore(events), nil
+}
+
+var getEventsFromSequenceQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT id, sequence, data, created_at FROM todo_events
+WHERE sequence IS NOT NULL AND sequence >= $1
+ORDER BY sequence ASC
+LIMIT $2
+`)
+
+// GetEventsFromSequence ...
+func (r *EventRepository) GetEventsFromSequence(ctx context.Context, seq uint64, limit uint64) ([]core.Event, error) {
+	var events []model.Event
+	err := r.db.SelectContext(ctx, &events, getEventsFromSequenceQuery, seq, limit)
+	if err != nil {
+		return nil, err
+	}
+	return modelEventsToCore(events), nil
+}
+
+// claimTimeout the max duration of a claim, the transaction is rolled back after it
+const claimTimeout = 30 * time.Second
+
+// sequenceLockID is the key of the transaction level advisory lock serializing the event processors
+const sequenceLockID int64 = 0x746f646f
+
+var lockSequenceQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT pg_advisory_xact_lock($1)
+`)
+
+var getUnprocessedEventsQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT id, data, created_at FROM todo_events
+WHERE sequence IS NULL
+ORDER BY id ASC
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`)
+
+// beginLocked begins a transaction holding the sequence lock, rolled back when cancel is called or after claimTimeout
+func (r *EventRepository) beginLocked() (*sqlx.Tx, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), claimTimeout)
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+
+	_, err = tx.Exec(lockSequenceQuery, sequenceLockID)
+	if err != nil {
+		_ = tx.Rollback()
+		cancel()
+		return nil, nil, err
+	}
+	return tx, cancel, nil
+}
+
+func (r *EventRepository) releaseClaim() {
+	if r.claimTx != nil {
+		_ = r.claimTx.Rollback()
+		r.claimCancel()
+		r.claimTx = nil
+		r.claimCancel = nil
+	}
+}
+
+// GetUnprocessedEvents claims the unprocessed events, releasing the previous claim
+func (r *EventRepository) GetUnprocessedEvents(limit uint64) ([]core.Event, error) {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	r.releaseClaim()
+
+	tx, cancel, err := r.beginLocked()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []model.Event
+	err = tx.Select(&events, getUnprocessedEventsQuery, limit)
+	if err != nil {
+		_ = tx.Rollback()
+		cancel()
+		return nil, err
+	}
+
+	if len(events) == 0 {
+		_ = tx.Rollback()
+		cancel()
+		return nil, nil
+	}
+
+	r.claimTx = tx
+	r.claimCancel = cancel
+	return modelEventsToCore(events), nil
+}
+
+var getLastSequenceQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT sequence FROM todo_publishers
+WHERE id = $1 AND partition_id = 0
+`)
+
+// GetLastSequence ...
+func (r *EventRepository) GetLastSequence(id core.PublisherID) (uint64, error) {
+	var result uint64
+	err := r.db.Get(&result, getLastSequenceQuery, id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+var saveLastSequence = dblib.NewDialectQuery(dblib.DialectPostgres, `
+INSERT INTO todo_publishers (id, partition_id, sequence)
+VALUES ($1, 0, $2)
+ON CONFLICT (id, partition_id) DO UPDATE SET sequence = excluded.sequence, updated_at = CURRENT_TIMESTAMP
+`)
+
+// SaveLastSequence ...
+func (r *EventRepository) SaveLastSequence(id core.PublisherID, seq uint64) error {
+	_, err := r.db.Exec(saveLastSequence, id, seq)
+	return err
+}
+
+var getMaxSequenceQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT COALESCE(MAX(sequence), 0) FROM todo_events
+`)
+
+var updateSequencesQuery = `
+UPDATE todo_events AS e SET sequence = v.sequence
+FROM (VALUES %s) AS v (id, sequence)
+WHERE e.id = v.id AND e.sequence IS NULL
+`
+
+var _ = dblib.NewDialectQuery(dblib.DialectPostgres,
+	fmt.Sprintf(updateSequencesQuery, "($1::BIGINT, $2::BIGINT)"))
+
+// UpdateSequences commits the sequences in the transaction claimed the events.
+// Returns an error when any of the events was sequenced by others
+// or the sequences do not continue the last sequence in the database,
+// the event processor then restarts from the events sequenced by others
+func (r *EventRepository) UpdateSequences(events []core.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	r.mut.Lock()
+	defer r.mut.Unlock()
+
+	tx, cancel := r.claimTx, r.claimCancel
+	r.claimTx, r.claimCancel = nil, nil
+	if tx == nil {
+		var err error
+		tx, cancel, err = r.beginLocked()
+		if err != nil {
+			return err
+		}
+	}
+	defer cancel()
+
+	var lastSequence uint64
+	err := tx.Get(&lastSequence, getMaxSequenceQuery)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if events[0].Sequence != lastSequence+1 {
+		_ = tx.Rollback()
+		return fmt.Errorf("postgres: events sequenced from %d, the last sequence is %d",
+			events[0].Sequence, lastSequence)
+	}
+
+	var buf strings.Builder
+	args := make([]interface{}, 0, 2*len(events))
+	for i, e := range events {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		fmt.Fprintf(&buf, "($%d::BIGINT, $%d::BIGINT)", 2*i+1, 2*i+2)
+		args = append(args, e.ID, e.Sequence)
+	}
+
+	query := fmt.Sprintf(updateSequencesQuery, buf.String())
+	res, err := tx.Exec(query, args...)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if affected != int64(len(events)) {
+		_ = tx.Rollback()
+		return fmt.Errorf("postgres: only %d of %d events are unprocessed", affected, len(events))
+	}
+
+	return tx.Commit()
+}
+
+var insertEventQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+INSERT INTO todo_events (aggregate_id, data) VALUES ($1, $2) RETURNING id
+`)
+
+// InsertEvent binds the data as []byte for storing a BYTEA
+func (r *EventTxnRepository) InsertEvent(ctx context.Context, event model.Event) (model.EventID, error) {
+	var id model.EventID
+	err := r.tx.GetContext(ctx, &id, insertEventQuery, event.AggregateID, []byte(event.Data))
+	if err != nil {
+		return 0, errors.WrapDBError(ctx, err)
+	}
+	return id, nil
+}
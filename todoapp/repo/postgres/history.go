@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todoapp/lib/dblib"
+	"todoapp/pkg/errors"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/types"
+)
+
+// HistoryRepository ...
+type HistoryRepository struct {
+	db *sqlx.DB
+}
+
+var _ types.TodoHistoryRepository = &HistoryRepository{}
+
+// NewHistoryRepository ...
+func NewHistoryRepository(db *sqlx.DB) *HistoryRepository {
+	return &HistoryRepository{
+		db: db,
+	}
+}
+
+var getTodoEventsQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT e.id, e.aggregate_id, e.sequence, e.data, e.created_at FROM (
+	SELECT id, aggregate_id, sequence, data, created_at FROM todo_events
+	WHERE aggregate_id = $1
+	ORDER BY id DESC
+	LIMIT $2
+) e ORDER BY e.id ASC
+`)
+
+// GetTodoEvents ...
+func (r *HistoryRepository) GetTodoEvents(ctx context.Context, todoID model.TodoID, limit uint64,
+) ([]model.Event, error) {
+	var result []model.Event
+	err := r.db.SelectContext(ctx, &result, getTodoEventsQuery, todoID, limit)
+	if err != nil {
+		return nil, errors.WrapDBError(ctx, err)
+	}
+	return result, nil
+}
+
+var getTodoEventBySequenceQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT id, aggregate_id, sequence, data, created_at FROM todo_events
+WHERE aggregate_id = $1 AND sequence <= $2
+ORDER BY sequence DESC
+LIMIT 1
+`)
+
+// GetTodoEventBySequence ...
+func (r *HistoryRepository) GetTodoEventBySequence(ctx context.Context, todoID model.TodoID, sequence uint64,
+) (model.NullEvent, error) {
+	return r.getTodoEvent(ctx, getTodoEventBySequenceQuery, todoID, sequence)
+}
+
+var getTodoEventByTimeQuery = dblib.NewDialectQuery(dblib.DialectPostgres, `
+SELECT id, aggregate_id, sequence, data, created_at FROM todo_events
+WHERE aggregate_id = $1 AND created_at <= $2
+ORDER BY id DESC
+LIMIT 1
+`)
+
+// GetTodoEventByTime ...
+func (r *HistoryRepository) GetTodoEventByTime(ctx context.Context, todoID model.TodoID, at time.Time,
+) (model.NullEvent, error) {
+	return r.getTodoEvent(ctx, getTodoEventByTimeQuery, todoID, at)
+}
+
+func (r *HistoryRepository) getTodoEvent(ctx context.Context, query string, args ...interface{},
+) (model.NullEvent, error) {
+	var event model.Event
+	err := r.db.GetContext(ctx, &event, query, args...)
+	if err == sql.ErrNoRows {
+		return model.NullEvent{}, nil
+	}
+	if err != nil {
+		return model.NullEvent{}, errors.WrapDBError(ctx, err)
+	}
+	return model.NullEvent{Valid: true, Event: event}, nil
+}
@@ -0,0 +1,253 @@
+package postgres_test
+
+import (
+	"context"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"sync"
+	"testing"
+	"time"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/repo/postgres"
+	"todoapp/todoapp/service"
+	"todoapp/todoapp/types"
+
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/lib/pq"
+)
+
+// Integration tests, run against a local Postgres with
+// TODOAPP_TEST_POSTGRES_DSN=postgres://postgres:1@localhost:5432/test?sslmode=disable
+const dsnEnv = "TODOAPP_TEST_POSTGRES_DSN"
+
+type nopEventClient struct {
+}
+
+func (nopEventClient) Signal(context.Context) {
+}
+
+func newTestDB(t *testing.T) *sqlx.DB {
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		t.Skip(dsnEnv + " is not set")
+	}
+
+	m, err := migrate.New("file://../../../migrations/postgres", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		t.Fatal(err)
+	}
+	_, _ = m.Close()
+
+	db := sqlx.MustConnect("postgres", dsn)
+	t.Cleanup(func() { _ = db.Close() })
+
+	db.MustExec(`TRUNCATE todos, todo_items, todo_events, todo_publishers RESTART IDENTITY`)
+	return db
+}
+
+func saveTodos(t *testing.T, db *sqlx.DB, n int) {
+	s := service.NewService(postgres.NewRepository(db), nopEventClient{})
+	for i := 0; i < n; i++ {
+		_, err := s.SaveTodo(context.Background(), types.SaveTodoInput{Name: "some todo"})
+		assert.Nil(t, err)
+	}
+}
+
+func setSequences(events []core.Event, from uint64) []core.Event {
+	for i := range events {
+		events[i].Sequence = from + uint64(i)
+	}
+	return events
+}
+
+func eventSequences(events []core.Event) []uint64 {
+	var result []uint64
+	for _, e := range events {
+		result = append(result, e.Sequence)
+	}
+	return result
+}
+
+func TestRepository_SaveTodo(t *testing.T) {
+	db := newTestDB(t)
+	repo := postgres.NewRepository(db)
+	s := service.NewService(repo, nopEventClient{})
+	ctx := context.Background()
+
+	id, err := s.SaveTodo(ctx, types.SaveTodoInput{
+		Name: "some todo",
+		Items: []model.TodoItem{
+			{Name: "item 1"},
+			{Name: "item 2"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, model.TodoID(1), id)
+
+	_, err = s.SaveTodo(ctx, types.SaveTodoInput{
+		ID:   id,
+		Name: "new todo",
+		Items: []model.TodoItem{
+			{ID: 2, Name: "new item 2"},
+		},
+	})
+	assert.Nil(t, err)
+
+	err = repo.Transact(ctx, func(tx types.TxnRepository) error {
+		nullTodo, err := tx.GetTodo(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, model.NullTodo{Valid: true, Todo: model.Todo{ID: id, Name: "new todo"}}, nullTodo)
+
+		items, err := tx.GetTodoItemsByTodoID(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.TodoItem{
+			{ID: 2, TodoID: id, Name: "new item 2"},
+		}, items)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func TestEventRepository(t *testing.T) {
+	db := newTestDB(t)
+	eventRepo := postgres.NewEventRepository(db)
+	saveTodos(t, db, 3)
+
+	events, err := eventRepo.GetUnprocessedEvents(10)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(events))
+
+	err = eventRepo.UpdateSequences(setSequences(events, 1))
+	assert.Nil(t, err)
+
+	unprocessed, err := eventRepo.GetUnprocessedEvents(10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(unprocessed))
+
+	last, err := eventRepo.GetLastEvents(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 3}, eventSequences(last))
+	assert.Equal(t, "some todo", types.Event(last[0]).Data.TodoSave.Name)
+
+	from, err := eventRepo.GetEventsFromSequence(context.Background(), 2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 3}, eventSequences(from))
+
+	seq, err := eventRepo.GetLastSequence(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), seq)
+
+	assert.Nil(t, eventRepo.SaveLastSequence(1, 2))
+	assert.Nil(t, eventRepo.SaveLastSequence(1, 3))
+
+	seq, err = eventRepo.GetLastSequence(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), seq)
+}
+
+type collectPublisher struct {
+	id core.PublisherID
+
+	mut    sync.Mutex
+	events []core.Event
+}
+
+func (p *collectPublisher) GetID() core.PublisherID {
+	return p.id
+}
+
+func (p *collectPublisher) Publish(events []core.Event) error {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func (p *collectPublisher) sequences() []uint64 {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return eventSequences(p.events)
+}
+
+func TestEventRepository_TwoCores(t *testing.T) {
+	db := newTestDB(t)
+
+	const todoCount = 50
+
+	publisher1 := &collectPublisher{id: 1}
+	publisher2 := &collectPublisher{id: 2}
+
+	core1 := core.NewCore(postgres.NewEventRepository(db),
+		core.AddPublisher(publisher1), core.WithErrorTimeout(10*time.Millisecond))
+	core2 := core.NewCore(postgres.NewEventRepository(db),
+		core.AddPublisher(publisher2), core.WithErrorTimeout(10*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		core1.Run(ctx)
+	}()
+	go func() {
+		defer wg.Done()
+		core2.Run(ctx)
+	}()
+
+	var saveWg sync.WaitGroup
+	saveWg.Add(todoCount)
+	for i := 0; i < todoCount; i++ {
+		go func() {
+			defer saveWg.Done()
+			saveTodos(t, db, 1)
+			core1.Signal()
+			core2.Signal()
+		}()
+	}
+	saveWg.Wait()
+
+	var expected []uint64
+	for seq := uint64(1); seq <= todoCount; seq++ {
+		expected = append(expected, seq)
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(publisher1.sequences()) >= todoCount && len(publisher2.sequences()) >= todoCount
+	}, 10*time.Second, 10*time.Millisecond)
+
+	cancel()
+	wg.Wait()
+
+	var sequences []uint64
+	err := db.Select(&sequences, `SELECT sequence FROM todo_events ORDER BY sequence ASC`)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, sequences)
+
+	assert.Equal(t, expected, publisher1.sequences())
+	assert.Equal(t, expected, publisher2.sequences())
+}
+
+func TestEventRepository_UpdateSequences_AlreadySequenced(t *testing.T) {
+	db := newTestDB(t)
+	saveTodos(t, db, 2)
+
+	eventRepo := postgres.NewEventRepository(db)
+	events, err := eventRepo.GetUnprocessedEvents(10)
+	assert.Nil(t, err)
+	assert.Nil(t, eventRepo.UpdateSequences(setSequences(events, 1)))
+
+	// without a claim, sequencing again MUST fail
+	err = eventRepo.UpdateSequences(setSequences(events, 3))
+	assert.NotNil(t, err)
+
+	from, err := eventRepo.GetEventsFromSequence(context.Background(), 1, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{1, 2}, eventSequences(from))
+}
@@ -0,0 +1,126 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"todoapp/lib/dblib"
+	"todoapp/pkg/errors"
+	"todoapp/todoapp/event/projector"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/types"
+)
+
+type (
+	// ProjectionRepository ...
+	ProjectionRepository struct {
+		db *sqlx.DB
+	}
+
+	// ProjectionTxnRepository ...
+	ProjectionTxnRepository struct {
+		tx *sqlx.Tx
+	}
+)
+
+var _ projector.Repository = &ProjectionRepository{}
+
+var _ projector.TxnRepository = &ProjectionTxnRepository{}
+
+var _ types.TodoSummaryRepository = &ProjectionRepository{}
+
+// NewProjectionRepository ...
+func NewProjectionRepository(db *sqlx.DB) *ProjectionRepository {
+	return &ProjectionRepository{
+		db: db,
+	}
+}
+
+// Transact ...
+func (r *ProjectionRepository) Transact(ctx context.Context, fn func(tx projector.TxnRepository) error) error {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	err = fn(&ProjectionTxnRepository{tx: tx})
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+var listTodoSummariesQuery = dblib.NewQuery(`
+SELECT id, name, item_count, last_sequence, created_at, updated_at FROM todo_summaries
+ORDER BY updated_at DESC, id DESC
+LIMIT ?
+`)
+
+// ListTodoSummaries ...
+func (r *ProjectionRepository) ListTodoSummaries(ctx context.Context, limit uint64) ([]model.TodoSummary, error) {
+	var result []model.TodoSummary
+	err := r.db.SelectContext(ctx, &result, listTodoSummariesQuery, limit)
+	if err != nil {
+		return nil, errors.WrapDBError(ctx, err)
+	}
+	return result, nil
+}
+
+var insertCheckpointQuery = dblib.NewQuery(`
+INSERT IGNORE INTO todo_projection_checkpoints (name, sequence) VALUES (?, 0)
+`)
+
+var getCheckpointQuery = dblib.NewQuery(`
+SELECT sequence FROM todo_projection_checkpoints
+WHERE name = ? FOR UPDATE
+`)
+
+// GetCheckpoint creates the checkpoint if not existed for locking
+func (r *ProjectionTxnRepository) GetCheckpoint(ctx context.Context, name string) (uint64, error) {
+	_, err := r.tx.ExecContext(ctx, insertCheckpointQuery, name)
+	if err != nil {
+		return 0, err
+	}
+
+	var result uint64
+	err = r.tx.GetContext(ctx, &result, getCheckpointQuery, name)
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+var saveCheckpointQuery = dblib.NewQuery(`
+UPDATE todo_projection_checkpoints SET sequence = ?
+WHERE name = ?
+`)
+
+// SaveCheckpoint ...
+func (r *ProjectionTxnRepository) SaveCheckpoint(ctx context.Context, name string, seq uint64) error {
+	_, err := r.tx.ExecContext(ctx, saveCheckpointQuery, seq, name)
+	return err
+}
+
+var upsertTodoSummaryQuery = dblib.NewNamedQuery(`
+INSERT INTO todo_summaries (id, name, item_count, last_sequence, created_at, updated_at)
+VALUES (:id, :name, :item_count, :last_sequence, :created_at, :updated_at) AS new
+ON DUPLICATE KEY UPDATE
+	name = new.name, item_count = new.item_count,
+	last_sequence = new.last_sequence, updated_at = new.updated_at
+`)
+
+// UpsertTodoSummary keeps created_at of the existing summary
+func (r *ProjectionTxnRepository) UpsertTodoSummary(ctx context.Context, summary model.TodoSummary) error {
+	_, err := r.tx.NamedExecContext(ctx, upsertTodoSummaryQuery, summary)
+	return err
+}
+
+var deleteAllTodoSummariesQuery = dblib.NewQuery(`
+DELETE FROM todo_summaries
+`)
+
+// DeleteAllTodoSummaries ...
+func (r *ProjectionTxnRepository) DeleteAllTodoSummaries(ctx context.Context) error {
+	_, err := r.tx.ExecContext(ctx, deleteAllTodoSummariesQuery)
+	return err
+}
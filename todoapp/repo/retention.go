@@ -0,0 +1,102 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todoapp/lib/dblib"
+	"todoapp/todoapp/event/archive"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+)
+
+// RetentionRepository ...
+type RetentionRepository struct {
+	db *sqlx.DB
+}
+
+var _ archive.Repository = &RetentionRepository{}
+
+// NewRetentionRepository ...
+func NewRetentionRepository(db *sqlx.DB) *RetentionRepository {
+	return &RetentionRepository{
+		db: db,
+	}
+}
+
+// GetFirstSequence ...
+func (r *RetentionRepository) GetFirstSequence(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := r.db.GetContext(ctx, &result, getFirstSequenceQuery)
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// GetLogHead ...
+func (r *RetentionRepository) GetLogHead(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := r.db.GetContext(ctx, &result, getLogHeadQuery)
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+var getLastSequenceCreatedBeforeQuery = dblib.NewQuery(`
+SELECT COALESCE(MAX(sequence), 0) FROM todo_events
+WHERE sequence IS NOT NULL AND created_at < ?
+`)
+
+// GetLastSequenceCreatedBefore ...
+func (r *RetentionRepository) GetLastSequenceCreatedBefore(ctx context.Context, before time.Time) (uint64, error) {
+	var result uint64
+	err := r.db.GetContext(ctx, &result, getLastSequenceCreatedBeforeQuery, before)
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+// GetPublisherSequence ...
+func (r *RetentionRepository) GetPublisherSequence(ctx context.Context, id core.PublisherID) (uint64, error) {
+	var result uint64
+	err := r.db.GetContext(ctx, &result, getLastSequenceQuery, id)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return result, nil
+}
+
+var getEventsInRangeQuery = dblib.NewQuery(`
+SELECT id, sequence, data, created_at FROM todo_events
+WHERE sequence >= ? AND sequence <= ?
+ORDER BY sequence ASC
+`)
+
+// GetEventsInRange ...
+func (r *RetentionRepository) GetEventsInRange(ctx context.Context, from uint64, to uint64,
+) ([]model.Event, error) {
+	var events []model.Event
+	err := r.db.SelectContext(ctx, &events, getEventsInRangeQuery, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+var deleteEventsInRangeQuery = dblib.NewQuery(`
+DELETE FROM todo_events
+WHERE sequence >= ? AND sequence <= ?
+`)
+
+// DeleteEventsInRange ...
+func (r *RetentionRepository) DeleteEventsInRange(ctx context.Context, from uint64, to uint64) error {
+	_, err := r.db.ExecContext(ctx, deleteEventsInRangeQuery, from, to)
+	return err
+}
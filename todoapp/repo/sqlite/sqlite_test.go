@@ -0,0 +1,219 @@
+package sqlite_test
+
+import (
+	"context"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"todoapp/todoapp/event/core"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/repo/sqlite"
+	"todoapp/todoapp/service"
+	"todoapp/todoapp/types"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+type nopEventClient struct {
+}
+
+func (nopEventClient) Signal(context.Context) {
+}
+
+func newTestDB(t *testing.T) *sqlx.DB {
+	dir, err := ioutil.TempDir("", "todoapp-sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	path := filepath.Join(dir, "todoapp.db")
+	m, err := migrate.New("file://../../../migrations/sqlite", "sqlite3://"+path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	_, _ = m.Close()
+
+	db := sqlx.MustConnect("sqlite3", "file:"+path+"?_busy_timeout=5000&_txlock=immediate")
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func TestRepository_SaveTodo(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlite.NewRepository(db)
+	s := service.NewService(repo, nopEventClient{})
+	ctx := context.Background()
+
+	id, err := s.SaveTodo(ctx, types.SaveTodoInput{
+		Name: "some todo",
+		Items: []model.TodoItem{
+			{Name: "item 1"},
+			{Name: "item 2"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, model.TodoID(1), id)
+
+	_, err = s.SaveTodo(ctx, types.SaveTodoInput{
+		ID:   id,
+		Name: "new todo",
+		Items: []model.TodoItem{
+			{ID: 2, Name: "new item 2"},
+		},
+	})
+	assert.Nil(t, err)
+
+	err = repo.Transact(ctx, func(tx types.TxnRepository) error {
+		nullTodo, err := tx.GetTodo(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, model.NullTodo{Valid: true, Todo: model.Todo{ID: id, Name: "new todo"}}, nullTodo)
+
+		items, err := tx.GetTodoItemsByTodoID(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.TodoItem{
+			{ID: 2, TodoID: id, Name: "new item 2"},
+		}, items)
+		return nil
+	})
+	assert.Nil(t, err)
+}
+
+func TestEventRepository(t *testing.T) {
+	db := newTestDB(t)
+	s := service.NewService(sqlite.NewRepository(db), nopEventClient{})
+	eventRepo := sqlite.NewEventRepository(db)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		_, err := s.SaveTodo(ctx, types.SaveTodoInput{Name: "some todo"})
+		assert.Nil(t, err)
+	}
+
+	events, err := eventRepo.GetUnprocessedEvents(10)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(events))
+
+	for i := range events {
+		events[i].Sequence = uint64(i + 1)
+	}
+	err = eventRepo.UpdateSequences(events)
+	assert.Nil(t, err)
+
+	unprocessed, err := eventRepo.GetUnprocessedEvents(10)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(unprocessed))
+
+	last, err := eventRepo.GetLastEvents(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 3}, eventSequences(last))
+	assert.Equal(t, "some todo", types.Event(last[0]).Data.TodoSave.Name)
+
+	from, err := eventRepo.GetEventsFromSequence(ctx, 2, 10)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint64{2, 3}, eventSequences(from))
+
+	seq, err := eventRepo.GetLastSequence(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), seq)
+
+	assert.Nil(t, eventRepo.SaveLastSequence(1, 2))
+	assert.Nil(t, eventRepo.SaveLastSequence(1, 3))
+
+	seq, err = eventRepo.GetLastSequence(1)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), seq)
+}
+
+func TestHistoryRepository_GetTodoEvents(t *testing.T) {
+	db := newTestDB(t)
+	s := service.NewService(sqlite.NewRepository(db), nopEventClient{},
+		service.WithTodoHistory(sqlite.NewHistoryRepository(db)),
+	)
+	ctx := context.Background()
+
+	id, err := s.SaveTodo(ctx, types.SaveTodoInput{Name: "some todo"})
+	assert.Nil(t, err)
+	_, err = s.SaveTodo(ctx, types.SaveTodoInput{Name: "other todo"})
+	assert.Nil(t, err)
+	_, err = s.SaveTodo(ctx, types.SaveTodoInput{ID: id, Name: "new todo"})
+	assert.Nil(t, err)
+
+	versions, err := s.GetTodoHistory(ctx, id)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(versions))
+	assert.Equal(t, model.EventID(1), versions[0].EventID)
+	assert.Equal(t, model.EventID(3), versions[1].EventID)
+	assert.Equal(t, []types.TodoFieldDiff{
+		{Field: "name", Old: "some todo", New: "new todo"},
+	}, versions[1].Diffs)
+}
+
+func TestService_RestoreTodo(t *testing.T) {
+	db := newTestDB(t)
+	repo := sqlite.NewRepository(db)
+	s := service.NewService(repo, nopEventClient{},
+		service.WithTodoHistory(sqlite.NewHistoryRepository(db)),
+	)
+	eventRepo := sqlite.NewEventRepository(db)
+	ctx := context.Background()
+
+	id, err := s.SaveTodo(ctx, types.SaveTodoInput{
+		Name:  "first name",
+		Items: []model.TodoItem{{Name: "item 1"}},
+	})
+	assert.Nil(t, err)
+	_, err = s.SaveTodo(ctx, types.SaveTodoInput{
+		ID:    id,
+		Name:  "second name",
+		Items: []model.TodoItem{{ID: 1, Name: "item 1"}},
+	})
+	assert.Nil(t, err)
+
+	events, err := eventRepo.GetUnprocessedEvents(10)
+	assert.Nil(t, err)
+	for i := range events {
+		events[i].Sequence = uint64(i + 1)
+	}
+	assert.Nil(t, eventRepo.UpdateSequences(events))
+
+	restoredID, err := s.RestoreTodo(ctx, types.RestoreTodoInput{ID: id, Sequence: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, id, restoredID)
+
+	err = repo.Transact(ctx, func(tx types.TxnRepository) error {
+		nullTodo, err := tx.GetTodo(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, "first name", nullTodo.Todo.Name)
+
+		items, err := tx.GetTodoItemsByTodoID(ctx, id)
+		assert.Nil(t, err)
+		assert.Equal(t, []model.TodoItem{{ID: 1, TodoID: id, Name: "item 1"}}, items)
+		return nil
+	})
+	assert.Nil(t, err)
+
+	versions, err := s.GetTodoHistory(ctx, id)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(versions))
+	assert.Equal(t, []types.TodoFieldDiff{
+		{Field: "name", Old: "second name", New: "first name"},
+	}, versions[2].Diffs)
+}
+
+func eventSequences(events []core.Event) []uint64 {
+	var result []uint64
+	for _, e := range events {
+		result = append(result, e.Sequence)
+	}
+	return result
+}
@@ -0,0 +1,150 @@
+package repo
+
+import (
+	"context"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"todoapp/lib/dblib"
+	"todoapp/todoapp/event/verify"
+)
+
+// VerifyRepository ...
+type VerifyRepository struct {
+	db *sqlx.DB
+}
+
+var _ verify.Repository = &VerifyRepository{}
+
+// NewVerifyRepository ...
+func NewVerifyRepository(db *sqlx.DB) *VerifyRepository {
+	return &VerifyRepository{
+		db: db,
+	}
+}
+
+var getLogHeadQuery = dblib.NewQuery(`
+SELECT COALESCE(MAX(sequence), 0) FROM todo_events
+`)
+
+// GetLogHead ...
+func (r *VerifyRepository) GetLogHead(ctx context.Context) (uint64, error) {
+	var head uint64
+	err := r.db.GetContext(ctx, &head, getLogHeadQuery)
+	if err != nil {
+		return 0, err
+	}
+	return head, nil
+}
+
+var getFirstSequenceQuery = dblib.NewQuery(`
+SELECT COALESCE(MIN(sequence), 0) FROM todo_events
+`)
+
+// GetFirstSequence ...
+func (r *VerifyRepository) GetFirstSequence(ctx context.Context) (uint64, error) {
+	var first uint64
+	err := r.db.GetContext(ctx, &first, getFirstSequenceQuery)
+	if err != nil {
+		return 0, err
+	}
+	return first, nil
+}
+
+var findSequenceGapsQuery = dblib.NewQuery(`
+SELECT e.prev_sequence + 1 AS from_sequence, e.sequence - 1 AS to_sequence FROM (
+	SELECT sequence, LAG(sequence) OVER (ORDER BY sequence) AS prev_sequence
+	FROM todo_events
+	WHERE sequence IS NOT NULL
+) e
+WHERE e.sequence > e.prev_sequence + 1
+ORDER BY e.sequence ASC
+LIMIT ?
+`)
+
+// FindSequenceGaps ...
+func (r *VerifyRepository) FindSequenceGaps(ctx context.Context, limit uint64) ([]verify.SequenceGap, error) {
+	var result []verify.SequenceGap
+	err := r.db.SelectContext(ctx, &result, findSequenceGapsQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+var findDuplicatedSequencesQuery = dblib.NewQuery(`
+SELECT sequence, COUNT(*) AS count FROM todo_events
+WHERE sequence IS NOT NULL
+GROUP BY sequence
+HAVING COUNT(*) > 1
+ORDER BY sequence ASC
+LIMIT ?
+`)
+
+// FindDuplicatedSequences ...
+func (r *VerifyRepository) FindDuplicatedSequences(ctx context.Context, limit uint64,
+) ([]verify.DuplicatedSequence, error) {
+	var result []verify.DuplicatedSequence
+	err := r.db.SelectContext(ctx, &result, findDuplicatedSequencesQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+var findOutOfOrderEventsQuery = dblib.NewQuery(`
+SELECT e.id, e.sequence, e.prev_id, e.prev_sequence FROM (
+	SELECT id, sequence,
+		LAG(id) OVER (ORDER BY id) AS prev_id,
+		LAG(sequence) OVER (ORDER BY id) AS prev_sequence
+	FROM todo_events
+	WHERE sequence IS NOT NULL
+) e
+WHERE e.sequence < e.prev_sequence
+ORDER BY e.id ASC
+LIMIT ?
+`)
+
+// FindOutOfOrderEvents ...
+func (r *VerifyRepository) FindOutOfOrderEvents(ctx context.Context, limit uint64,
+) ([]verify.OutOfOrderEvent, error) {
+	var result []verify.OutOfOrderEvent
+	err := r.db.SelectContext(ctx, &result, findOutOfOrderEventsQuery, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+var findStaleUnprocessedEventsQuery = dblib.NewQuery(`
+SELECT id, created_at FROM todo_events
+WHERE sequence IS NULL AND created_at < ?
+ORDER BY id ASC
+LIMIT ?
+`)
+
+// FindStaleUnprocessedEvents ...
+func (r *VerifyRepository) FindStaleUnprocessedEvents(ctx context.Context, before time.Time, limit uint64,
+) ([]verify.StaleEvent, error) {
+	var result []verify.StaleEvent
+	err := r.db.SelectContext(ctx, &result, findStaleUnprocessedEventsQuery, before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+var getPublisherSequencesQuery = dblib.NewQuery(`
+SELECT id, sequence FROM todo_publishers
+WHERE partition_id = 0
+ORDER BY id ASC
+`)
+
+// GetPublisherSequences ...
+func (r *VerifyRepository) GetPublisherSequences(ctx context.Context) ([]verify.PublisherSequence, error) {
+	var result []verify.PublisherSequence
+	err := r.db.SelectContext(ctx, &result, getPublisherSequencesQuery)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -3,17 +3,21 @@ package server
 import (
 	"context"
 	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
-	"todoapp/todoapp/event/core"
 )
 
+// EventCore is signaled by the todoapp servers after inserting events
+type EventCore interface {
+	Signal()
+}
+
 // EventServer ...
 type EventServer struct {
 	todoapp_rpc.UnimplementedEventServiceServer
-	core *core.Core
+	core EventCore
 }
 
 // NewEventServer ...
-func NewEventServer(core *core.Core) *EventServer {
+func NewEventServer(core EventCore) *EventServer {
 	return &EventServer{
 		core: core,
 	}
@@ -2,16 +2,17 @@ package server
 
 import (
 	"github.com/jmoiron/sqlx"
-	"google.golang.org/grpc"
-	"todoapp/todoapp/client"
 	"todoapp/todoapp/repo"
 	"todoapp/todoapp/service"
+	"todoapp/todoapp/types"
 )
 
 // InitServer initializes server
-func InitServer(db *sqlx.DB, conn *grpc.ClientConn) *Server {
-	repoInstance := repo.NewRepository(db)
-	clientInstance := client.NewEventClient(conn)
-	s := service.NewService(repoInstance, clientInstance)
+func InitServer(db *sqlx.DB, eventClient types.EventClient, options ...service.Option) *Server {
+	repoInstance := repo.NewTodoRepository(db)
+	options = append([]service.Option{
+		service.WithTodoHistory(repo.NewTodoHistoryRepository(db)),
+	}, options...)
+	s := service.NewService(repoInstance, eventClient, options...)
 	return NewServer(s)
 }
@@ -2,8 +2,8 @@ package server
 
 import (
 	"context"
-	"github.com/golang/protobuf/ptypes"
 	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
+	"todoapp/todoapp/model"
 	"todoapp/todoapp/types"
 )
 
@@ -41,16 +41,28 @@ func (s *Server) Save(ctx context.Context, req *todoapp_rpc.TodoSaveRequest,
 	}, nil
 }
 
-// List for listing
+// List lists todos from the todo summary projection
 func (s *Server) List(ctx context.Context, req *todoapp_rpc.TodoListRequest,
 ) (*todoapp_rpc.TodoListResponse, error) {
+	summaries, err := s.service.ListTodos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	return &todoapp_rpc.TodoListResponse{
-		Todos: []*todoapp_rpc.TodoData{
-			{
-				Id:        100,
-				Name:      "Quang Tung",
-				CreatedAt: ptypes.TimestampNow(),
-			},
-		},
+		Todos: transformTodoSummaries(summaries),
+	}, nil
+}
+
+// GetTodoHistory returns the versions of a todo saved by its events, oldest first
+func (s *Server) GetTodoHistory(ctx context.Context, req *todoapp_rpc.GetTodoHistoryRequest,
+) (*todoapp_rpc.GetTodoHistoryResponse, error) {
+	versions, err := s.service.GetTodoHistory(ctx, model.TodoID(req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	return &todoapp_rpc.GetTodoHistoryResponse{
+		Versions: transformTodoVersions(versions),
 	}, nil
 }
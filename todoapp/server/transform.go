@@ -1,6 +1,7 @@
 package server
 
 import (
+	"github.com/golang/protobuf/ptypes"
 	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
 	"todoapp/todoapp/model"
 	"todoapp/todoapp/types"
@@ -24,3 +25,57 @@ func transformSaveRequest(req *todoapp_rpc.TodoSaveRequest) (types.SaveTodoInput
 		Items: transformTodoItems(req.Items),
 	}, nil
 }
+
+func transformTodoSummaries(summaries []model.TodoSummary) []*todoapp_rpc.TodoData {
+	result := make([]*todoapp_rpc.TodoData, 0, len(summaries))
+	for _, s := range summaries {
+		createdAt, err := ptypes.TimestampProto(s.CreatedAt)
+		if err != nil {
+			panic(err)
+		}
+
+		result = append(result, &todoapp_rpc.TodoData{
+			Id:        int64(s.ID),
+			Name:      s.Name,
+			CreatedAt: createdAt,
+		})
+	}
+	return result
+}
+
+func transformTodoVersions(versions []types.TodoVersion) []*todoapp_rpc.TodoVersion {
+	result := make([]*todoapp_rpc.TodoVersion, 0, len(versions))
+	for _, v := range versions {
+		createdAt, err := ptypes.TimestampProto(v.CreatedAt)
+		if err != nil {
+			panic(err)
+		}
+
+		items := make([]*todoapp_rpc.TodoItem, 0, len(v.Items))
+		for _, item := range v.Items {
+			items = append(items, &todoapp_rpc.TodoItem{
+				Id:   int64(item.ID),
+				Name: item.Name,
+			})
+		}
+
+		diffs := make([]*todoapp_rpc.TodoFieldDiff, 0, len(v.Diffs))
+		for _, d := range v.Diffs {
+			diffs = append(diffs, &todoapp_rpc.TodoFieldDiff{
+				Field: d.Field,
+				Old:   d.Old,
+				New:   d.New,
+			})
+		}
+
+		result = append(result, &todoapp_rpc.TodoVersion{
+			EventId:   uint64(v.EventID),
+			Sequence:  v.Sequence,
+			CreatedAt: createdAt,
+			Name:      v.Name,
+			Items:     items,
+			Diffs:     diffs,
+		})
+	}
+	return result
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
+	"todoapp/todoapp/model"
+	"todoapp/todoapp/types"
+)
+
+func TestTransformSaveRequest(t *testing.T) {
+	input, err := transformSaveRequest(&todoapp_rpc.TodoSaveRequest{
+		Id:   10,
+		Name: "some todo",
+		Items: []*todoapp_rpc.TodoItem{
+			{Id: 3, Name: "item 1"},
+			{Name: "item 2"},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, types.SaveTodoInput{
+		ID:   10,
+		Name: "some todo",
+		Items: []model.TodoItem{
+			{ID: 3, Name: "item 1"},
+			{Name: "item 2"},
+		},
+	}, input)
+}
+
+func TestTransformTodoVersions(t *testing.T) {
+	result := transformTodoVersions([]types.TodoVersion{
+		{
+			EventID:   21,
+			Sequence:  5,
+			CreatedAt: time.Date(2021, 1, 10, 8, 0, 0, 0, time.UTC),
+			Name:      "some todo",
+			Items: []model.TodoItem{
+				{ID: 33, TodoID: 10, Name: "item 1"},
+			},
+			Diffs: []types.TodoFieldDiff{
+				{Field: "items.33", Old: "", New: "item 1"},
+			},
+		},
+	})
+	assert.Equal(t, []*todoapp_rpc.TodoVersion{
+		{
+			EventId:   21,
+			Sequence:  5,
+			CreatedAt: &timestamp.Timestamp{Seconds: 1610265600},
+			Name:      "some todo",
+			Items: []*todoapp_rpc.TodoItem{
+				{Id: 33, Name: "item 1"},
+			},
+			Diffs: []*todoapp_rpc.TodoFieldDiff{
+				{Field: "items.33", Old: "", New: "item 1"},
+			},
+		},
+	}, result)
+}
@@ -0,0 +1,52 @@
+package service
+
+import "todoapp/todoapp/types"
+
+// Option ...
+type Option func(opts *serviceOpts)
+
+type serviceOpts struct {
+	summaries    types.TodoSummaryRepository
+	listLimit    uint64
+	history      types.TodoHistoryRepository
+	historyLimit uint64
+}
+
+var defaultServiceOpts = serviceOpts{
+	listLimit:    1000,
+	historyLimit: 100,
+}
+
+// WithTodoSummaries serves ListTodos from the todo summary projection
+func WithTodoSummaries(repo types.TodoSummaryRepository) Option {
+	return func(opts *serviceOpts) {
+		opts.summaries = repo
+	}
+}
+
+// WithListLimit the max number of todos returned by ListTodos
+func WithListLimit(limit uint64) Option {
+	return func(opts *serviceOpts) {
+		opts.listLimit = limit
+	}
+}
+
+// WithTodoHistory serves GetTodoHistory from the event log
+func WithTodoHistory(repo types.TodoHistoryRepository) Option {
+	return func(opts *serviceOpts) {
+		opts.history = repo
+	}
+}
+
+// WithHistoryLimit the max number of versions returned by GetTodoHistory
+func WithHistoryLimit(limit uint64) Option {
+	return func(opts *serviceOpts) {
+		opts.historyLimit = limit
+	}
+}
+
+func applyOptions(opts *serviceOpts, options ...Option) {
+	for _, o := range options {
+		o(opts)
+	}
+}
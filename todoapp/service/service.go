@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	todoapp_rpc "todoapp-rpc/rpc/todoapp/v1"
 	"todoapp/pkg/errors"
 	"todoapp/todoapp/model"
@@ -13,31 +15,71 @@ import (
 type Service struct {
 	repo   types.Repository
 	client types.EventClient
+
+	// options
+	summaries    types.TodoSummaryRepository
+	listLimit    uint64
+	history      types.TodoHistoryRepository
+	historyLimit uint64
 }
 
 var _ types.Service = &Service{}
 
 // NewService creates a service
-func NewService(repo types.Repository, client types.EventClient) *Service {
+func NewService(repo types.Repository, client types.EventClient, options ...Option) *Service {
+	opts := defaultServiceOpts
+	applyOptions(&opts, options...)
+
 	return &Service{
 		repo:   repo,
 		client: client,
+
+		summaries:    opts.summaries,
+		listLimit:    opts.listLimit,
+		history:      opts.history,
+		historyLimit: opts.historyLimit,
 	}
 }
 
 // BuildTodoSaveEvent ...
+// the event carries the todo with all its items as saved, so a version of the todo can be restored
 func BuildTodoSaveEvent(input types.SaveTodoInput) model.Event {
+	items := make([]*todoapp_rpc.EventTodoItem, 0, len(input.Items))
+	for _, item := range input.Items {
+		items = append(items, &todoapp_rpc.EventTodoItem{
+			Id:   uint64(item.ID),
+			Name: item.Name,
+		})
+	}
+
 	return types.Event{
 		Data: &todoapp_rpc.Event{
 			Type: todoapp_rpc.EventType_EVENT_TYPE_TODO_SAVE,
 			TodoSave: &todoapp_rpc.EventTodoSave{
-				Id:   uint64(input.ID),
-				Name: input.Name,
+				Id:    uint64(input.ID),
+				Name:  input.Name,
+				Items: items,
 			},
 		},
 	}.ToModel()
 }
 
+// withInsertedItemIDs returns the items with the IDs of the inserted ones, ids are in the order of the new items
+func withInsertedItemIDs(items []model.TodoItem, ids []model.TodoItemID) []model.TodoItem {
+	result := make([]model.TodoItem, 0, len(items))
+	for _, item := range items {
+		if item.ID == 0 {
+			item.ID = ids[0]
+			ids = ids[1:]
+		}
+		result = append(result, model.TodoItem{
+			ID:   item.ID,
+			Name: item.Name,
+		})
+	}
+	return result
+}
+
 func saveTodoTx(
 	ctx context.Context, input types.SaveTodoInput,
 	tx types.TxnRepository, eventTx types.EventTxnRepository,
@@ -50,15 +92,18 @@ func saveTodoTx(
 			return 0, err
 		}
 
+		insertedIDs := make([]model.TodoItemID, 0, len(input.Items))
 		for _, item := range input.Items {
 			item.TodoID = id
-			_, err := tx.InsertTodoItem(ctx, item)
+			itemID, err := tx.InsertTodoItem(ctx, item)
 			if err != nil {
 				return 0, err
 			}
+			insertedIDs = append(insertedIDs, itemID)
 		}
 
 		input.ID = id
+		input.Items = withInsertedItemIDs(input.Items, insertedIDs)
 		_, err = eventTx.InsertEvent(ctx, BuildTodoSaveEvent(input))
 		if err != nil {
 			return 0, err
@@ -72,7 +117,7 @@ func saveTodoTx(
 		return 0, err
 	}
 	if !nullTodo.Valid {
-		return 0, errors.Todo.NotFoundTodo.Err()
+		return 0, errors.Todo.NotFoundTodo.WithTodoId(int64(input.ID)).Err()
 	}
 
 	items, err := tx.GetTodoItemsByTodoID(ctx, input.ID)
@@ -105,13 +150,16 @@ func saveTodoTx(
 		}
 	}
 
+	insertedIDs := make([]model.TodoItemID, 0, len(actions.InsertedItems))
 	for _, item := range actions.InsertedItems {
-		_, err := tx.InsertTodoItem(ctx, item)
+		itemID, err := tx.InsertTodoItem(ctx, item)
 		if err != nil {
 			return 0, err
 		}
+		insertedIDs = append(insertedIDs, itemID)
 	}
 
+	input.Items = withInsertedItemIDs(input.Items, insertedIDs)
 	_, err = eventTx.InsertEvent(ctx, BuildTodoSaveEvent(input))
 	if err != nil {
 		return 0, err
@@ -143,3 +191,206 @@ func (s *Service) SaveTodo(ctx context.Context, input types.SaveTodoInput) (mode
 
 	return todoID, nil
 }
+
+// ListTodos lists todos from the todo summary projection, most recently updated first
+func (s *Service) ListTodos(ctx context.Context) ([]model.TodoSummary, error) {
+	if s.summaries == nil {
+		return nil, errors.Todo.UnimplementedListTodos.Err()
+	}
+	return s.summaries.ListTodoSummaries(ctx, s.listLimit)
+}
+
+// GetTodoHistory returns the versions of a todo saved by its events, oldest first
+// events moved out by the retention job are not included
+func (s *Service) GetTodoHistory(ctx context.Context, id model.TodoID) ([]types.TodoVersion, error) {
+	if s.history == nil {
+		return nil, errors.Todo.UnimplementedTodoHistory.Err()
+	}
+
+	// one more event to compute the diffs of the oldest returned version
+	events, err := s.history.GetTodoEvents(ctx, id, s.historyLimit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, errors.Todo.NotFoundTodo.WithTodoId(int64(id)).Err()
+	}
+
+	versions := computeTodoVersions(events)
+	if uint64(len(versions)) > s.historyLimit {
+		versions = versions[len(versions)-int(s.historyLimit):]
+	}
+	return versions, nil
+}
+
+// todoItemField the field of an item in the diffs of todo versions
+func todoItemField(id uint64) string {
+	return fmt.Sprintf("items.%d", id)
+}
+
+func todoItemNames(save *todoapp_rpc.EventTodoSave) map[uint64]string {
+	result := make(map[uint64]string, len(save.Items))
+	for _, item := range save.Items {
+		result[item.Id] = item.Name
+	}
+	return result
+}
+
+// diffTodoVersions returns the changed name then the added, renamed and deleted items ordered by ID,
+// a nil prev is the empty todo before the first version
+func diffTodoVersions(prev *todoapp_rpc.EventTodoSave, save *todoapp_rpc.EventTodoSave) []types.TodoFieldDiff {
+	if prev == nil {
+		prev = &todoapp_rpc.EventTodoSave{}
+	}
+
+	var diffs []types.TodoFieldDiff
+	if prev.Name != save.Name {
+		diffs = append(diffs, types.TodoFieldDiff{
+			Field: "name",
+			Old:   prev.Name,
+			New:   save.Name,
+		})
+	}
+
+	oldItems := todoItemNames(prev)
+	newItems := todoItemNames(save)
+
+	ids := make([]uint64, 0, len(oldItems)+len(newItems))
+	for id := range oldItems {
+		ids = append(ids, id)
+	}
+	for id := range newItems {
+		if _, existed := oldItems[id]; !existed {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		oldName, oldExisted := oldItems[id]
+		newName, newExisted := newItems[id]
+		if oldExisted == newExisted && oldName == newName {
+			continue
+		}
+		diffs = append(diffs, types.TodoFieldDiff{
+			Field: todoItemField(id),
+			Old:   oldName,
+			New:   newName,
+		})
+	}
+	return diffs
+}
+
+func computeTodoVersions(events []model.Event) []types.TodoVersion {
+	versions := make([]types.TodoVersion, 0, len(events))
+	var prev *todoapp_rpc.EventTodoSave
+	for _, e := range events {
+		event := types.EventFromModel(e)
+		if event.Data.Type != todoapp_rpc.EventType_EVENT_TYPE_TODO_SAVE {
+			continue
+		}
+		save := event.Data.TodoSave
+
+		var items []model.TodoItem
+		for _, item := range save.Items {
+			items = append(items, model.TodoItem{
+				ID:     model.TodoItemID(item.Id),
+				TodoID: model.TodoID(save.Id),
+				Name:   item.Name,
+			})
+		}
+
+		versions = append(versions, types.TodoVersion{
+			EventID:   e.ID,
+			Sequence:  uint64(e.Sequence.Int64),
+			CreatedAt: e.CreatedAt,
+			Name:      save.Name,
+			Items:     items,
+			Diffs:     diffTodoVersions(prev, save),
+		})
+		prev = save
+	}
+	return versions
+}
+
+// restoredTodoItems returns the items of the event, the ones no longer in current without IDs
+func restoredTodoItems(saved []*todoapp_rpc.EventTodoItem, current []model.TodoItem) []model.TodoItem {
+	existed := make(map[model.TodoItemID]struct{}, len(current))
+	for _, item := range current {
+		existed[item.ID] = struct{}{}
+	}
+
+	result := make([]model.TodoItem, 0, len(saved))
+	for _, item := range saved {
+		id := model.TodoItemID(item.Id)
+		if _, ok := existed[id]; !ok {
+			id = 0
+		}
+		result = append(result, model.TodoItem{
+			ID:   id,
+			Name: item.Name,
+		})
+	}
+	return result
+}
+
+// RestoreTodo saves the todo with its items as it was at a sequence or a time, the restore is saved as a new event,
+// items deleted since then are inserted again with new IDs
+func (s *Service) RestoreTodo(ctx context.Context, input types.RestoreTodoInput) (model.TodoID, error) {
+	if s.history == nil {
+		return 0, errors.Todo.UnimplementedTodoHistory.Err()
+	}
+	if (input.Sequence == 0) == input.Time.IsZero() {
+		return 0, errors.Todo.InvalidArgumentRestorePoint.Err()
+	}
+
+	var nullEvent model.NullEvent
+	var err error
+	if input.Sequence != 0 {
+		nullEvent, err = s.history.GetTodoEventBySequence(ctx, input.ID, input.Sequence)
+	} else {
+		nullEvent, err = s.history.GetTodoEventByTime(ctx, input.ID, input.Time)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !nullEvent.Valid {
+		return 0, errors.Todo.NotFoundTodoVersion.Err()
+	}
+
+	event := types.EventFromModel(nullEvent.Event)
+	if event.Data.Type != todoapp_rpc.EventType_EVENT_TYPE_TODO_SAVE {
+		return 0, errors.Todo.NotFoundTodoVersion.Err()
+	}
+	save := event.Data.TodoSave
+
+	err = s.repo.Transact(ctx, func(tx types.TxnRepository) error {
+		// lock the todo before reading its items, for a concurrent save to not change them until the restore is saved
+		nullTodo, err := tx.GetTodo(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+		if !nullTodo.Valid {
+			return errors.Todo.NotFoundTodo.WithTodoId(int64(input.ID)).Err()
+		}
+
+		items, err := tx.GetTodoItemsByTodoID(ctx, input.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = saveTodoTx(ctx, types.SaveTodoInput{
+			ID:    input.ID,
+			Name:  save.Name,
+			Items: restoredTodoItems(save.Items, items),
+		}, tx, tx.ToEventRepository())
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	s.client.Signal(ctx)
+
+	return input.ID, nil
+}
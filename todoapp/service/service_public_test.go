@@ -5,6 +5,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 	"todoapp/pkg/errors"
 	types_mocks "todoapp/todoapp/mocks"
 	"todoapp/todoapp/model"
@@ -73,6 +74,9 @@ func TestService_SaveTodo_Insert_OK(t *testing.T) {
 
 	newInput := input
 	newInput.ID = 555
+	newInput.Items = []model.TodoItem{
+		{ID: 33, Name: "some item"},
+	}
 	service.InsertEventHelper(mockEventRepo, service.BuildTodoSaveEvent(newInput), 88, nil)
 
 	mockClient.EXPECT().Signal(gomock.Any())
@@ -87,3 +91,312 @@ func TestService_SaveTodo_Insert_OK(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, model.TodoID(555), id)
 }
+
+func TestService_ListTodos(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+	mockSummaries := types_mocks.NewMockTodoSummaryRepository(ctrl)
+
+	summaries := []model.TodoSummary{
+		{ID: 12, Name: "some todo", ItemCount: 3},
+	}
+	mockSummaries.EXPECT().ListTodoSummaries(gomock.Any(), uint64(20)).Return(summaries, nil)
+
+	s := service.NewService(mockRepo, mockClient,
+		service.WithTodoSummaries(mockSummaries),
+		service.WithListLimit(20),
+	)
+	result, err := s.ListTodos(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, summaries, result)
+}
+
+func TestService_ListTodos_WithoutProjection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+
+	s := service.NewService(mockRepo, mockClient)
+	result, err := s.ListTodos(context.Background())
+	assert.Equal(t, errors.Todo.UnimplementedListTodos.Err(), err)
+	assert.Nil(t, result)
+}
+
+func historyEvent(id model.EventID, name string, items ...model.TodoItem) model.Event {
+	e := service.BuildTodoSaveEvent(types.SaveTodoInput{ID: 12, Name: name, Items: items})
+	e.ID = id
+	return e
+}
+
+func TestService_GetTodoHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+	mockHistory := types_mocks.NewMockTodoHistoryRepository(ctrl)
+
+	s := service.NewService(mockRepo, mockClient,
+		service.WithTodoHistory(mockHistory),
+		service.WithHistoryLimit(2),
+	)
+
+	table := []struct {
+		name     string
+		events   []model.Event
+		expected []types.TodoVersion
+	}{
+		{
+			name:   "first-version",
+			events: []model.Event{historyEvent(1, "todo")},
+			expected: []types.TodoVersion{
+				{
+					EventID: 1, Name: "todo",
+					Diffs: []types.TodoFieldDiff{{Field: "name", Old: "", New: "todo"}},
+				},
+			},
+		},
+		{
+			name: "unchanged",
+			events: []model.Event{
+				historyEvent(1, "todo"),
+				historyEvent(2, "todo"),
+			},
+			expected: []types.TodoVersion{
+				{
+					EventID: 1, Name: "todo",
+					Diffs: []types.TodoFieldDiff{{Field: "name", Old: "", New: "todo"}},
+				},
+				{EventID: 2, Name: "todo"},
+			},
+		},
+		{
+			name: "items",
+			events: []model.Event{
+				historyEvent(1, "todo",
+					model.TodoItem{ID: 33, Name: "item 1"},
+					model.TodoItem{ID: 44, Name: "item 2"},
+				),
+				historyEvent(2, "todo",
+					model.TodoItem{ID: 33, Name: "new item 1"},
+					model.TodoItem{ID: 55, Name: "item 3"},
+				),
+			},
+			expected: []types.TodoVersion{
+				{
+					EventID: 1, Name: "todo",
+					Items: []model.TodoItem{
+						{ID: 33, TodoID: 12, Name: "item 1"},
+						{ID: 44, TodoID: 12, Name: "item 2"},
+					},
+					Diffs: []types.TodoFieldDiff{
+						{Field: "name", Old: "", New: "todo"},
+						{Field: "items.33", Old: "", New: "item 1"},
+						{Field: "items.44", Old: "", New: "item 2"},
+					},
+				},
+				{
+					EventID: 2, Name: "todo",
+					Items: []model.TodoItem{
+						{ID: 33, TodoID: 12, Name: "new item 1"},
+						{ID: 55, TodoID: 12, Name: "item 3"},
+					},
+					Diffs: []types.TodoFieldDiff{
+						{Field: "items.33", Old: "item 1", New: "new item 1"},
+						{Field: "items.44", Old: "item 2", New: ""},
+						{Field: "items.55", Old: "", New: "item 3"},
+					},
+				},
+			},
+		},
+		{
+			name: "over-limit",
+			events: []model.Event{
+				historyEvent(1, "a"),
+				historyEvent(2, "b"),
+				historyEvent(3, "c"),
+			},
+			expected: []types.TodoVersion{
+				{
+					EventID: 2, Name: "b",
+					Diffs: []types.TodoFieldDiff{{Field: "name", Old: "a", New: "b"}},
+				},
+				{
+					EventID: 3, Name: "c",
+					Diffs: []types.TodoFieldDiff{{Field: "name", Old: "b", New: "c"}},
+				},
+			},
+		},
+	}
+
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			mockHistory.EXPECT().GetTodoEvents(gomock.Any(), model.TodoID(12), uint64(3)).Return(e.events, nil)
+
+			result, err := s.GetTodoHistory(context.Background(), 12)
+			assert.Nil(t, err)
+			assert.Equal(t, e.expected, result)
+		})
+	}
+}
+
+func TestService_GetTodoHistory_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+	mockHistory := types_mocks.NewMockTodoHistoryRepository(ctrl)
+
+	mockHistory.EXPECT().GetTodoEvents(gomock.Any(), model.TodoID(12), uint64(101)).Return(nil, nil)
+
+	s := service.NewService(mockRepo, mockClient, service.WithTodoHistory(mockHistory))
+	result, err := s.GetTodoHistory(context.Background(), 12)
+	assert.Equal(t, errors.Todo.NotFoundTodo.WithTodoId(12).Err(), err)
+	assert.Nil(t, result)
+}
+
+func TestService_GetTodoHistory_WithoutHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+
+	s := service.NewService(mockRepo, mockClient)
+	result, err := s.GetTodoHistory(context.Background(), 12)
+	assert.Equal(t, errors.Todo.UnimplementedTodoHistory.Err(), err)
+	assert.Nil(t, result)
+}
+
+func TestService_RestoreTodo_InvalidRestorePoint(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+	mockHistory := types_mocks.NewMockTodoHistoryRepository(ctrl)
+
+	s := service.NewService(mockRepo, mockClient, service.WithTodoHistory(mockHistory))
+
+	table := []struct {
+		name  string
+		input types.RestoreTodoInput
+	}{
+		{name: "none", input: types.RestoreTodoInput{ID: 12}},
+		{name: "both", input: types.RestoreTodoInput{ID: 12, Sequence: 3, Time: time.Now()}},
+	}
+	for _, e := range table {
+		t.Run(e.name, func(t *testing.T) {
+			id, err := s.RestoreTodo(context.Background(), e.input)
+			assert.Equal(t, errors.Todo.InvalidArgumentRestorePoint.Err(), err)
+			assert.Equal(t, model.TodoID(0), id)
+		})
+	}
+}
+
+func TestService_RestoreTodo_NotFoundVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+	mockHistory := types_mocks.NewMockTodoHistoryRepository(ctrl)
+
+	at := time.Date(2021, 1, 10, 8, 0, 0, 0, time.UTC)
+	mockHistory.EXPECT().GetTodoEventByTime(gomock.Any(), model.TodoID(12), at).Return(model.NullEvent{}, nil)
+
+	s := service.NewService(mockRepo, mockClient, service.WithTodoHistory(mockHistory))
+	id, err := s.RestoreTodo(context.Background(), types.RestoreTodoInput{ID: 12, Time: at})
+	assert.Equal(t, errors.Todo.NotFoundTodoVersion.Err(), err)
+	assert.Equal(t, model.TodoID(0), id)
+}
+
+func TestService_RestoreTodo_NotFoundTodo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+	mockHistory := types_mocks.NewMockTodoHistoryRepository(ctrl)
+	mockTx := types_mocks.NewMockTxnRepository(ctrl)
+
+	restored := service.BuildTodoSaveEvent(types.SaveTodoInput{ID: 12, Name: "old todo"})
+	mockHistory.EXPECT().GetTodoEventBySequence(gomock.Any(), model.TodoID(12), uint64(5)).
+		Return(model.NullEvent{Valid: true, Event: restored}, nil)
+
+	mockRepo.EXPECT().Transact(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(tx types.TxnRepository) error) error {
+			return fn(mockTx)
+		})
+	service.GetTodoHelper(mockTx, 12, model.NullTodo{}, nil)
+
+	s := service.NewService(mockRepo, mockClient, service.WithTodoHistory(mockHistory))
+	id, err := s.RestoreTodo(context.Background(), types.RestoreTodoInput{ID: 12, Sequence: 5})
+	assert.Equal(t, errors.Todo.NotFoundTodo.WithTodoId(12).Err(), err)
+	assert.Equal(t, model.TodoID(0), id)
+}
+
+func TestService_RestoreTodo_OK(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := types_mocks.NewMockRepository(ctrl)
+	mockClient := types_mocks.NewMockEventClient(ctrl)
+	mockHistory := types_mocks.NewMockTodoHistoryRepository(ctrl)
+	mockTx := types_mocks.NewMockTxnRepository(ctrl)
+	mockEventRepo := types_mocks.NewMockEventTxnRepository(ctrl)
+
+	restored := service.BuildTodoSaveEvent(types.SaveTodoInput{
+		ID:   12,
+		Name: "old todo",
+		Items: []model.TodoItem{
+			{ID: 33, Name: "old item 1"},
+			{ID: 44, Name: "old item 2"},
+		},
+	})
+	mockHistory.EXPECT().GetTodoEventBySequence(gomock.Any(), model.TodoID(12), uint64(5)).
+		Return(model.NullEvent{Valid: true, Event: restored}, nil)
+
+	mockRepo.EXPECT().Transact(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(tx types.TxnRepository) error) error {
+			return fn(mockTx)
+		})
+
+	// item 44 was deleted after the restored version, item 55 was added
+	currentItems := []model.TodoItem{
+		{ID: 33, TodoID: 12, Name: "new item 1"},
+		{ID: 55, TodoID: 12, Name: "new item 3"},
+	}
+	mockTx.EXPECT().ToEventRepository().Return(mockEventRepo)
+	service.GetTodoItemsHelper(mockTx, 12, currentItems, nil).Times(2)
+	service.GetTodoHelper(mockTx, 12, model.NullTodo{
+		Valid: true,
+		Todo:  model.Todo{ID: 12, Name: "new todo"},
+	}, nil).Times(2)
+	service.UpdateTodoHelper(mockTx, model.Todo{ID: 12, Name: "old todo"}, nil)
+	service.DeleteItemsHelper(mockTx, []model.TodoItemID{55}, nil)
+	service.UpdateItemHelper(mockTx, model.TodoItem{ID: 33, Name: "old item 1"}, nil)
+	service.InsertItemHelper(mockTx, model.TodoItem{TodoID: 12, Name: "old item 2"}, 66, nil)
+
+	service.InsertEventHelper(mockEventRepo, service.BuildTodoSaveEvent(types.SaveTodoInput{
+		ID:   12,
+		Name: "old todo",
+		Items: []model.TodoItem{
+			{ID: 33, Name: "old item 1"},
+			{ID: 66, Name: "old item 2"},
+		},
+	}), 90, nil)
+
+	mockClient.EXPECT().Signal(gomock.Any())
+
+	s := service.NewService(mockRepo, mockClient, service.WithTodoHistory(mockHistory))
+	id, err := s.RestoreTodo(context.Background(), types.RestoreTodoInput{ID: 12, Sequence: 5})
+	assert.Nil(t, err)
+	assert.Equal(t, model.TodoID(12), id)
+}
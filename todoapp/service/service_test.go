@@ -89,7 +89,7 @@ func TestTodoSaveTx_Error(t *testing.T) {
 			expectCall: func(e testCase, tx *types_mocks.MockTxnRepository, eventTx *types_mocks.MockEventTxnRepository) {
 				GetTodoHelper(tx, 11, model.NullTodo{}, nil)
 			},
-			expectedErr: errors.Todo.NotFoundTodo.Err(),
+			expectedErr: errors.Todo.NotFoundTodo.WithTodoId(11).Err(),
 		},
 		{
 			name: "get-todo-with-error",
@@ -474,6 +474,10 @@ func TestTodoSaveTx_Error(t *testing.T) {
 
 				newInput := e.input
 				newInput.ID = 55
+				newInput.Items = []model.TodoItem{
+					{ID: 1, Name: "new item 4"},
+					{ID: 2, Name: "new item 5"},
+				}
 				InsertEventHelper(eventTx, BuildTodoSaveEvent(newInput), 31,
 					errors.General.InternalErrorAccessingDatabase.Err())
 			},
@@ -511,6 +515,10 @@ func TestTodoSaveTx_Error(t *testing.T) {
 
 				newInput := e.input
 				newInput.ID = 55
+				newInput.Items = []model.TodoItem{
+					{ID: 1, Name: "new item 4"},
+					{ID: 2, Name: "new item 5"},
+				}
 				InsertEventHelper(eventTx, BuildTodoSaveEvent(newInput), 31, nil)
 			},
 			expectedErr: nil,
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"sync/atomic"
+)
+
+// Codec the compression codec of event payloads
+//
+// The codec is stored as the first byte of a compressed payload.
+// A protobuf message never starts with a byte smaller than 0x08 (field number 0 is invalid),
+// so payloads without a marker are raw protobuf bytes written by older versions.
+type Codec byte
+
+const (
+	// CodecNone stores raw protobuf bytes
+	CodecNone Codec = 0x00
+	// CodecGzip compresses with gzip
+	CodecGzip Codec = 0x01
+
+	maxCodecMarker = 0x07
+)
+
+// ParseCodec parses the codec name from config
+func ParseCodec(name string) (Codec, error) {
+	switch name {
+	case "", "none":
+		return CodecNone, nil
+	case "gzip":
+		return CodecGzip, nil
+	default:
+		return CodecNone, fmt.Errorf("unsupported event compression codec '%s'", name)
+	}
+}
+
+// EventCompression configures compression of event payloads in ToModel
+type EventCompression struct {
+	Codec Codec
+	// payloads smaller than Threshold bytes are stored uncompressed
+	Threshold int
+}
+
+var eventCompression atomic.Value
+
+func init() {
+	eventCompression.Store(EventCompression{Codec: CodecNone})
+}
+
+// SetEventCompression MUST be called before any event is created
+func SetEventCompression(c EventCompression) {
+	eventCompression.Store(c)
+}
+
+func getEventCompression() EventCompression {
+	return eventCompression.Load().(EventCompression)
+}
+
+func compressEventData(data []byte, c EventCompression) []byte {
+	if c.Codec == CodecNone || len(data) < c.Threshold {
+		return data
+	}
+
+	var buf bytes.Buffer
+	buf.WriteByte(byte(CodecGzip))
+
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(data)
+	if err != nil {
+		panic(err)
+	}
+	err = w.Close()
+	if err != nil {
+		panic(err)
+	}
+
+	if buf.Len() >= len(data) {
+		return data
+	}
+	return buf.Bytes()
+}
+
+func decompressEventData(data []byte) ([]byte, error) {
+	if len(data) == 0 || data[0] > maxCodecMarker {
+		return data, nil
+	}
+
+	switch Codec(data[0]) {
+	case CodecGzip:
+		r, err := gzip.NewReader(bytes.NewReader(data[1:]))
+		if err != nil {
+			return nil, err
+		}
+		result, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		return result, r.Close()
+
+	default:
+		return nil, fmt.Errorf("unrecognized event codec marker '%d'", data[0])
+	}
+}
@@ -0,0 +1,58 @@
+package types
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCompressEventData(t *testing.T) {
+	data := []byte(strings.Repeat("\x0a\x05hello", 100))
+
+	compressed := compressEventData(data, EventCompression{Codec: CodecGzip, Threshold: 100})
+	assert.Equal(t, byte(CodecGzip), compressed[0])
+	assert.True(t, len(compressed) < len(data))
+
+	result, err := decompressEventData(compressed)
+	assert.Nil(t, err)
+	assert.Equal(t, data, result)
+}
+
+func TestCompressEventData_Threshold(t *testing.T) {
+	data := []byte("\x0a\x05hello")
+
+	compressed := compressEventData(data, EventCompression{Codec: CodecGzip, Threshold: 100})
+	assert.Equal(t, data, compressed)
+
+	compressed = compressEventData(data, EventCompression{Codec: CodecNone})
+	assert.Equal(t, data, compressed)
+}
+
+func TestDecompressEventData_Uncompressed(t *testing.T) {
+	data := []byte("\x08\x01\x12\x05hello")
+	result, err := decompressEventData(data)
+	assert.Nil(t, err)
+	assert.Equal(t, data, result)
+
+	result, err = decompressEventData(nil)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(result))
+}
+
+func TestDecompressEventData_Unrecognized(t *testing.T) {
+	_, err := decompressEventData([]byte{0x05, 0x01})
+	assert.Equal(t, "unrecognized event codec marker '5'", err.Error())
+}
+
+func TestParseCodec(t *testing.T) {
+	c, err := ParseCodec("gzip")
+	assert.Nil(t, err)
+	assert.Equal(t, CodecGzip, c)
+
+	c, err = ParseCodec("")
+	assert.Nil(t, err)
+	assert.Equal(t, CodecNone, c)
+
+	_, err = ParseCodec("lz4")
+	assert.Equal(t, "unsupported event compression codec 'lz4'", err.Error())
+}
@@ -22,9 +22,11 @@ func (e Event) ToModel() model.Event {
 	if err != nil {
 		panic(err)
 	}
+	data = compressEventData(data, getEventCompression())
 
 	return model.Event{
-		ID: e.ID,
+		ID:          e.ID,
+		AggregateID: e.AggregateID(),
 		Sequence: sql.NullInt64{
 			Valid: true,
 			Int64: int64(e.Sequence),
@@ -34,10 +36,29 @@ func (e Event) ToModel() model.Event {
 	}
 }
 
+// AggregateID the ID of the aggregate changed by the event, e.g. the todo ID,
+// null when the event does not change an aggregate
+func (e Event) AggregateID() sql.NullInt64 {
+	switch e.Data.Type {
+	case todoapp_rpc.EventType_EVENT_TYPE_TODO_SAVE:
+		return sql.NullInt64{
+			Valid: true,
+			Int64: int64(e.Data.TodoSave.Id),
+		}
+	default:
+		return sql.NullInt64{}
+	}
+}
+
 // EventFromModel ...
 func EventFromModel(e model.Event) Event {
+	raw, err := decompressEventData([]byte(e.Data))
+	if err != nil {
+		panic(err)
+	}
+
 	data := &todoapp_rpc.Event{}
-	err := proto.Unmarshal([]byte(e.Data), data)
+	err = proto.Unmarshal(raw, data)
 	if err != nil {
 		panic(err)
 	}
@@ -2,11 +2,14 @@
 //go:generate mockgen -destination=../mocks/txn_repository.go -package=types_mocks . TxnRepository
 //go:generate mockgen -destination=../mocks/event_txn_repository.go -package=types_mocks . EventTxnRepository
 //go:generate mockgen -destination=../mocks/event_client.go -package=types_mocks . EventClient
+//go:generate mockgen -destination=../mocks/todo_summary_repository.go -package=types_mocks . TodoSummaryRepository
+//go:generate mockgen -destination=../mocks/todo_history_repository.go -package=types_mocks . TodoHistoryRepository
 
 package types
 
 import (
 	"context"
+	"time"
 	"todoapp/todoapp/model"
 )
 
@@ -14,6 +17,9 @@ type (
 	// Service ...
 	Service interface {
 		SaveTodo(ctx context.Context, input SaveTodoInput) (model.TodoID, error)
+		ListTodos(ctx context.Context) ([]model.TodoSummary, error)
+		GetTodoHistory(ctx context.Context, id model.TodoID) ([]TodoVersion, error)
+		RestoreTodo(ctx context.Context, input RestoreTodoInput) (model.TodoID, error)
 	}
 
 	// Repository ...
@@ -46,4 +52,21 @@ type (
 	EventClient interface {
 		Signal(ctx context.Context)
 	}
+
+	// TodoSummaryRepository reads the todo summary projection
+	TodoSummaryRepository interface {
+		ListTodoSummaries(ctx context.Context, limit uint64) ([]model.TodoSummary, error)
+	}
+
+	// TodoHistoryRepository reads the events of a todo
+	TodoHistoryRepository interface {
+		// GetTodoEvents returns the last limit events of the todo in insertion order
+		GetTodoEvents(ctx context.Context, todoID model.TodoID, limit uint64) ([]model.Event, error)
+
+		// GetTodoEventBySequence returns the last event of the todo with sequence <= the sequence
+		GetTodoEventBySequence(ctx context.Context, todoID model.TodoID, sequence uint64) (model.NullEvent, error)
+
+		// GetTodoEventByTime returns the last event of the todo created at or before the time
+		GetTodoEventByTime(ctx context.Context, todoID model.TodoID, at time.Time) (model.NullEvent, error)
+	}
 )
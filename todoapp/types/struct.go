@@ -1,6 +1,9 @@
 package types
 
-import "todoapp/todoapp/model"
+import (
+	"time"
+	"todoapp/todoapp/model"
+)
 
 type (
 	// SaveTodoInput ...
@@ -9,4 +12,31 @@ type (
 		Name  string
 		Items []model.TodoItem
 	}
+
+	// RestoreTodoInput exactly one of Sequence and Time must be set
+	RestoreTodoInput struct {
+		ID       model.TodoID
+		Sequence uint64
+		Time     time.Time
+	}
+
+	// TodoFieldDiff a changed field between two versions of a todo,
+	// the field of an item is items.<item id>, Old is empty for added items and New for deleted items
+	TodoFieldDiff struct {
+		Field string
+		Old   string
+		New   string
+	}
+
+	// TodoVersion a version of a todo saved by an event
+	TodoVersion struct {
+		EventID model.EventID
+		// zero when the event is not sequenced yet
+		Sequence  uint64
+		CreatedAt time.Time
+		Name      string
+		Items     []model.TodoItem
+		// against the previous version, the first version has all fields changed from empty
+		Diffs []TodoFieldDiff
+	}
 )
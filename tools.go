@@ -3,8 +3,6 @@
 package tools
 
 import (
-	_ "github.com/QuangTung97/eventcore"
-	_ "github.com/cheekybits/genny"
 	_ "github.com/kisielk/errcheck"
 	_ "golang.org/x/lint/golint"
 )